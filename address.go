@@ -0,0 +1,187 @@
+package domainer
+
+import "net"
+
+var (
+	// thisNetwork is the RFC 1122 "this network" block 0.0.0.0/8, which some systems route to the local host.
+	thisNetwork = mustParseCIDR("0.0.0.0/8")
+
+	// sharedNetwork is the RFC 6598 shared address space 100.64.0.0/10, used for carrier-grade NAT
+	// and by cloud providers for internal services like metadata endpoints.
+	sharedNetwork = mustParseCIDR("100.64.0.0/10")
+
+	// benchmarkNetwork is the RFC 2544 benchmarking block 198.18.0.0/15.
+	benchmarkNetwork = mustParseCIDR("198.18.0.0/15")
+
+	// reservedNetwork is the RFC 1112 block 240.0.0.0/4 reserved for future use, including the broadcast address.
+	reservedNetwork = mustParseCIDR("240.0.0.0/4")
+
+	// nat64Network is the RFC 6052 well-known NAT64 prefix 64:ff9b::/96, which embeds an IPv4 address.
+	nat64Network = mustParseCIDR("64:ff9b::/96")
+)
+
+// mustParseCIDR parses a network that is known to be valid.
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+
+	return network
+}
+
+// AddressClass is the kind of network an IP address belongs to.
+type AddressClass int
+
+const (
+	// AddressUnknown is used if there is no address to classify.
+	AddressUnknown AddressClass = iota
+
+	// AddressPublic is a publicly routable address.
+	AddressPublic
+
+	// AddressUnspecified is the unspecified address, like "0.0.0.0" or "::".
+	AddressUnspecified
+
+	// AddressLoopback is a loopback address, like "127.0.0.1" or "::1".
+	AddressLoopback
+
+	// AddressLinkLocal is a link-local address, like "169.254.169.254" or "fe80::1".
+	AddressLinkLocal
+
+	// AddressPrivate is an RFC 1918 private IPv4 address, like "10.0.0.1" or "192.168.0.1".
+	AddressPrivate
+
+	// AddressUniqueLocal is an RFC 4193 unique local IPv6 address, like "fd00::1".
+	AddressUniqueLocal
+
+	// AddressMulticast is a multicast address, like "224.0.0.1" or "ff02::1".
+	AddressMulticast
+
+	// AddressShared is an RFC 6598 shared address used for carrier-grade NAT, like "100.64.0.1".
+	// Cloud providers use this range for internal services, like "100.100.100.200".
+	AddressShared
+
+	// AddressBenchmark is an RFC 2544 address reserved for benchmarking, like "198.18.0.1".
+	AddressBenchmark
+
+	// AddressReserved is an address of a block that is not routable, like "0.1.2.3" in 0.0.0.0/8 or "240.0.0.1".
+	AddressReserved
+
+	// AddressBroadcast is the limited broadcast address "255.255.255.255".
+	AddressBroadcast
+)
+
+// String returns the name of the address class.
+func (c AddressClass) String() string {
+	switch c {
+	case AddressPublic:
+		return "public"
+	case AddressUnspecified:
+		return "unspecified"
+	case AddressLoopback:
+		return "loopback"
+	case AddressLinkLocal:
+		return "link-local"
+	case AddressPrivate:
+		return "private"
+	case AddressUniqueLocal:
+		return "unique-local"
+	case AddressMulticast:
+		return "multicast"
+	case AddressShared:
+		return "shared"
+	case AddressBenchmark:
+		return "benchmark"
+	case AddressReserved:
+		return "reserved"
+	case AddressBroadcast:
+		return "broadcast"
+	default:
+		return "unknown"
+	}
+}
+
+// ClassifyIP returns the class of the given IP address.
+// IPv6 addresses with the NAT64 prefix 64:ff9b::/96 are classified by the IPv4 address they embed.
+// Example: AddressLinkLocal for "64:ff9b::a9fe:a9fe", which embeds "169.254.169.254"
+func ClassifyIP(ip net.IP) AddressClass {
+	if ip != nil && ip.To4() == nil && nat64Network.Contains(ip) {
+		ip = net.IP(ip[len(ip)-net.IPv4len:])
+	}
+
+	switch {
+	case ip == nil:
+		return AddressUnknown
+	case ip.Equal(net.IPv4bcast):
+		return AddressBroadcast
+	case ip.IsUnspecified():
+		return AddressUnspecified
+	case ip.IsLoopback():
+		return AddressLoopback
+	case ip.IsMulticast():
+		return AddressMulticast
+	case ip.IsLinkLocalUnicast():
+		return AddressLinkLocal
+	case ip.IsPrivate() && ip.To4() != nil:
+		return AddressPrivate
+	case ip.IsPrivate():
+		return AddressUniqueLocal
+	case sharedNetwork.Contains(ip):
+		return AddressShared
+	case benchmarkNetwork.Contains(ip):
+		return AddressBenchmark
+	case thisNetwork.Contains(ip), reservedNetwork.Contains(ip):
+		return AddressReserved
+	default:
+		return AddressPublic
+	}
+}
+
+// AddressClass returns the class of the addresses the host resolves to.
+// If the host resolves to multiple addresses, the first one that is not public decides.
+func (u *URL) AddressClass() AddressClass {
+	class, _ := u.addressClass()
+	return class
+}
+
+// IsPublic reports whether all addresses the host resolves to are publicly routable.
+// Like RejectPrivate, it only checks the addresses resolved at parse time.
+func (u *URL) IsPublic() bool {
+	return u.AddressClass() == AddressPublic
+}
+
+// IsPrivate reports whether any address the host resolves to is not publicly routable.
+func (u *URL) IsPrivate() bool {
+	class := u.AddressClass()
+	return class != AddressPublic && class != AddressUnknown
+}
+
+// addressClass returns the class of the addresses the host resolves to and the address that decided it.
+func (u *URL) addressClass() (AddressClass, string) {
+	addresses := u.IPAddresses
+	if len(addresses) == 0 && u.IPAddress != "" {
+		addresses = []string{u.IPAddress}
+	}
+
+	class, decidingAddress := AddressUnknown, ""
+	for _, address := range addresses {
+		c := ClassifyIP(net.ParseIP(address))
+		if c != AddressPublic {
+			return c, address
+		}
+		class, decidingAddress = c, address
+	}
+
+	return class, decidingAddress
+}
+
+// checkPublic returns a *PrivateAddressError if the host resolves to an address that is not public.
+func (u *URL) checkPublic() error {
+	class, address := u.addressClass()
+	if class == AddressPublic {
+		return nil
+	}
+
+	return &PrivateAddressError{Host: u.host(), IP: address, Class: class}
+}
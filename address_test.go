@@ -0,0 +1,128 @@
+package domainer
+
+import (
+	"errors"
+	"net"
+	"testing"
+)
+
+var classifyTests = []struct {
+	ip       string
+	expected AddressClass
+}{
+	{"93.184.216.34", AddressPublic},
+	{"2606:2800:220:1:248:1893:25c8:1946", AddressPublic},
+	{"0.0.0.0", AddressUnspecified},
+	{"::", AddressUnspecified},
+	{"127.0.0.1", AddressLoopback},
+	{"::1", AddressLoopback},
+	{"::ffff:127.0.0.1", AddressLoopback},
+	{"169.254.169.254", AddressLinkLocal},
+	{"fe80::1", AddressLinkLocal},
+	{"10.0.0.1", AddressPrivate},
+	{"172.16.0.1", AddressPrivate},
+	{"192.168.0.1", AddressPrivate},
+	{"fd00::1", AddressUniqueLocal},
+	{"224.0.0.1", AddressMulticast},
+	{"ff02::1", AddressMulticast},
+	{"100.64.0.1", AddressShared},
+	{"100.100.100.200", AddressShared},
+	{"100.128.0.1", AddressPublic},
+	{"198.18.0.1", AddressBenchmark},
+	{"198.19.255.255", AddressBenchmark},
+	{"0.1.2.3", AddressReserved},
+	{"240.0.0.1", AddressReserved},
+	{"255.255.255.255", AddressBroadcast},
+	{"64:ff9b::a9fe:a9fe", AddressLinkLocal},
+	{"64:ff9b::7f00:1", AddressLoopback},
+	{"64:ff9b::5db8:d822", AddressPublic},
+}
+
+func TestClassifyIP(t *testing.T) {
+	for _, tt := range classifyTests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := ClassifyIP(net.ParseIP(tt.ip)); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+
+	if ClassifyIP(nil) != AddressUnknown {
+		t.Errorf("Expected '%s', got '%s'", AddressUnknown, ClassifyIP(nil))
+	}
+}
+
+func TestAddressClass(t *testing.T) {
+	u := &URL{IPAddresses: []string{"93.184.216.34", "10.0.0.1"}}
+	if u.AddressClass() != AddressPrivate {
+		t.Errorf("Expected '%s', got '%s'", AddressPrivate, u.AddressClass())
+	}
+	if !u.IsPrivate() || u.IsPublic() {
+		t.Errorf("Expected private URL")
+	}
+
+	u = &URL{IPAddress: "93.184.216.34"}
+	if !u.IsPublic() || u.IsPrivate() {
+		t.Errorf("Expected public URL")
+	}
+
+	u = &URL{}
+	if u.AddressClass() != AddressUnknown || u.IsPublic() || u.IsPrivate() {
+		t.Errorf("Expected unknown URL")
+	}
+}
+
+func TestRejectPrivate(t *testing.T) {
+	stubLookup(t)
+
+	rejectTests := []struct {
+		domain string
+		class  AddressClass
+	}{
+		{"http://127.0.0.1:8080/", AddressLoopback},
+		{"http://[::1]/", AddressLoopback},
+		{"http://169.254.169.254/latest/meta-data/", AddressLinkLocal},
+		{"http://10.1.2.3/", AddressPrivate},
+	}
+
+	for _, tt := range rejectTests {
+		t.Run(tt.domain, func(t *testing.T) {
+			_, err := FromString(tt.domain, RejectPrivate())
+			if !errors.Is(err, ErrPrivateAddress) {
+				t.Fatalf("Expected '%v', got '%v'", ErrPrivateAddress, err)
+			}
+
+			var privateErr *PrivateAddressError
+			if !errors.As(err, &privateErr) {
+				t.Fatalf("Expected *PrivateAddressError, got %T", err)
+			}
+			if privateErr.Class != tt.class {
+				t.Errorf("Class: Expected '%s', got '%s'", tt.class, privateErr.Class)
+			}
+		})
+	}
+
+	u, err := FromString("https://hooks.example.com/webhook", RejectPrivate())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !u.IsPublic() {
+		t.Errorf("Expected public URL")
+	}
+}
+
+func TestRejectPrivateResolved(t *testing.T) {
+	stubResolver(t, &staticResolver{ips: []net.IP{net.IPv4(93, 184, 216, 34), net.IPv4(192, 168, 1, 1)}})
+
+	if _, err := FromString("https://internal.example.com", RejectPrivate()); !errors.Is(err, ErrPrivateAddress) {
+		t.Errorf("Expected '%v', got '%v'", ErrPrivateAddress, err)
+	}
+
+	u, err := FromString("https://internal.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(u.IPAddresses) != 2 || u.IPAddress != "93.184.216.34" {
+		t.Errorf("Expected both addresses, got %q", u.IPAddresses)
+	}
+}
@@ -0,0 +1,170 @@
+package domainer
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Builder constructs a URL from its parts.
+// All parts are given unencoded and are percent-encoded when the URL is built.
+// Example: NewBuilder().Scheme("https").Subdomain("api").Domain("example").TLD("co.uk").Path("/v1/users").Build()
+type Builder struct {
+	// u holds the decoded parts set so far.
+	u URL
+
+	// host is the full host, if it has been set with Host.
+	host string
+}
+
+// NewBuilder returns an empty Builder.
+func NewBuilder() *Builder {
+	return &Builder{u: URL{decoded: true}}
+}
+
+// Scheme sets the protocol.
+// Example: "https"
+func (b *Builder) Scheme(scheme string) *Builder {
+	b.u.Protocol = strings.ToLower(scheme)
+	return b
+}
+
+// User sets the username and password.
+// Example: "user", "p@ss"
+func (b *Builder) User(username, password string) *Builder {
+	b.u.Username = username
+	b.u.Password = password
+	return b
+}
+
+// Host sets the full host, which is split into subdomain, domain and tld when the URL is built.
+// It replaces the values set with Subdomain, Domain and TLD. Build fails if the host contains a port, path,
+// query, fragment or credentials.
+// Example: "api.example.co.uk"
+func (b *Builder) Host(host string) *Builder {
+	b.host = host
+	return b
+}
+
+// Subdomain sets the subdomain.
+// Example: "api"
+func (b *Builder) Subdomain(subdomain string) *Builder {
+	b.u.Subdomain = subdomain
+	return b
+}
+
+// Domain sets the domain name (or second level domain).
+// Example: "example"
+func (b *Builder) Domain(domain string) *Builder {
+	b.u.Domain = domain
+	return b
+}
+
+// TLD sets the top level domain.
+// Example: "co.uk"
+func (b *Builder) TLD(tld string) *Builder {
+	b.u.TLD = tld
+	return b
+}
+
+// Port sets the port.
+// Example: 8443
+func (b *Builder) Port(port int) *Builder {
+	b.u.Port = port
+	return b
+}
+
+// Path sets the path. A leading slash is added if it is missing.
+// Example: "/v1/users"
+func (b *Builder) Path(path string) *Builder {
+	if path != "" && !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	b.u.Path = path
+	return b
+}
+
+// AddQuery appends a key-value pair to the query.
+// Example: "page", "2"
+func (b *Builder) AddQuery(key, value string) *Builder {
+	b.u.Query = append(b.u.Query, Query{
+		Key:   url.QueryEscape(key),
+		Value: url.QueryEscape(value),
+	})
+	return b
+}
+
+// Fragment sets the fragment.
+// Example: "section-2"
+func (b *Builder) Fragment(fragment string) *Builder {
+	b.u.Fragment = fragment
+	return b
+}
+
+// Build assembles the URL and parses it with FromString, using the given Option values.
+// It fails if the parts don't make up a valid URL, for example if the tld is not a public suffix.
+// Building only validates the parts. The host is resolved if an option like RejectPrivate needs its addresses.
+// The builder can be changed and built again without affecting URLs that have already been built.
+func (b *Builder) Build(opts ...Option) (*URL, error) {
+	u := b.u
+
+	if b.host != "" {
+		u.Subdomain, u.Domain, u.TLD = "", b.host, ""
+	}
+	if u.Domain == "" {
+		return nil, ErrNoHost
+	}
+	if b.host == "" && strings.Contains(u.Domain, ".") {
+		return nil, fmt.Errorf("%w: domain %q must be a single label", ErrPublicSuffix, u.Domain)
+	}
+
+	if host := u.host(); !validHost(host) {
+		return nil, fmt.Errorf("domainer: invalid host %q", host)
+	}
+
+	if u.Protocol != "" && !hasScheme(u.Protocol+"://") {
+		return nil, fmt.Errorf("domainer: invalid scheme %q", u.Protocol)
+	}
+	if u.Port < 0 || u.Port > 65535 {
+		port := strconv.Itoa(u.Port)
+		return nil, &InvalidPortError{Input: port, Err: fmt.Errorf("port %s out of range", port)}
+	}
+
+	parsed, err := FromString(u.String(), append([]Option{withoutLookup()}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure nothing of the host has been taken for another part of the URL
+	if !strings.EqualFold(parsed.host(), u.host()) {
+		return nil, fmt.Errorf("domainer: host %q is parsed as %q", u.host(), parsed.host())
+	}
+
+	// Make sure the host has been split the way it has been given
+	if b.host == "" && (!strings.EqualFold(parsed.Subdomain, u.Subdomain) ||
+		!strings.EqualFold(parsed.Domain, u.Domain) ||
+		!strings.EqualFold(parsed.TLD, u.TLD)) {
+		return nil, fmt.Errorf("%w: %q is split into subdomain %q, domain %q and tld %q",
+			ErrPublicSuffix, u.host(), parsed.Subdomain, parsed.Domain, parsed.TLD)
+	}
+
+	return parsed, nil
+}
+
+// validHost reports whether the host can't spill over into another part of the URL.
+// Colons are only allowed in IPv6 addresses.
+// Example: "example.com/admin" and "example.com:8080" are not valid
+func validHost(host string) bool {
+	if strings.ContainsAny(host, "/?#@\\") {
+		return false
+	}
+
+	if strings.Contains(host, ":") {
+		return net.ParseIP(strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")) != nil
+	}
+
+	return true
+}
@@ -0,0 +1,192 @@
+package domainer
+
+import (
+	"sort"
+	"strings"
+)
+
+// CompareOptions controls which differences are ignored when comparing two URLs.
+type CompareOptions struct {
+	// IgnoreCredentials ignores the Username and Password of both URLs.
+	IgnoreCredentials bool
+
+	// IgnoreDefaultPort treats a missing port and the default port of the protocol as equal.
+	// Example: "https://example.com" and "https://example.com:443"
+	IgnoreDefaultPort bool
+
+	// IgnoreTrailingSlash treats paths that only differ in a trailing slash as equal.
+	// Example: "https://example.com/search" and "https://example.com/search/"
+	IgnoreTrailingSlash bool
+
+	// IgnoreQueryOrder compares the query parameters regardless of their order.
+	// Example: "?a=1&b=2" and "?b=2&a=1"
+	IgnoreQueryOrder bool
+
+	// IgnoreFragment ignores the Fragment of both URLs.
+	IgnoreFragment bool
+}
+
+// Equal reports whether a and b point to the same resource.
+// Protocol and host are compared case-insensitively, everything else must match exactly after normalizing the
+// percent-encoding, so "/%7Euser" equals "/~user" and a URL parsed with DecodeValues equals the same URL parsed
+// without. FullURL and IPAddress are not taken into account.
+func Equal(a, b *URL) bool {
+	return a.EquivalentTo(b, CompareOptions{})
+}
+
+// EquivalentTo reports whether u and other point to the same resource, ignoring the differences
+// selected in opts.
+func (u *URL) EquivalentTo(other *URL, opts CompareOptions) bool {
+	if u == nil || other == nil {
+		return u == other
+	}
+
+	if !strings.EqualFold(u.Protocol, other.Protocol) {
+		return false
+	}
+
+	if !strings.EqualFold(u.Subdomain, other.Subdomain) ||
+		!strings.EqualFold(u.Domain, other.Domain) ||
+		!strings.EqualFold(u.TLD, other.TLD) {
+		return false
+	}
+
+	if opts.IgnoreDefaultPort {
+		if u.effectivePort() != other.effectivePort() {
+			return false
+		}
+	} else if u.Port != other.Port {
+		return false
+	}
+
+	uUsername, uPassword, uPath, uFragment := u.normalizedValues()
+	otherUsername, otherPassword, otherPath, otherFragment := other.normalizedValues()
+
+	if opts.IgnoreTrailingSlash {
+		uPath = strings.TrimSuffix(uPath, "/")
+		otherPath = strings.TrimSuffix(otherPath, "/")
+	}
+	if uPath != otherPath {
+		return false
+	}
+
+	if !equalQuery(u.Query, other.Query, opts.IgnoreQueryOrder) {
+		return false
+	}
+
+	if !opts.IgnoreFragment && uFragment != otherFragment {
+		return false
+	}
+
+	if !opts.IgnoreCredentials && (uUsername != otherUsername || uPassword != otherPassword) {
+		return false
+	}
+
+	return true
+}
+
+// equalQuery reports whether both query lists contain the same key-value pairs.
+func equalQuery(a, b []Query, ignoreOrder bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	a = normalizedQuery(a)
+	b = normalizedQuery(b)
+
+	if ignoreOrder {
+		sortQuery(a)
+		sortQuery(b)
+	}
+
+	for i := range a {
+		if a[i].Key != b[i].Key || a[i].Value != b[i].Value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// normalizedQuery returns a copy of the given query list with keys and values normalized with normalizePercent.
+func normalizedQuery(query []Query) []Query {
+	normalized := make([]Query, len(query))
+	for i, q := range query {
+		normalized[i] = Query{Key: normalizePercent(q.Key), Value: normalizePercent(q.Value)}
+	}
+
+	return normalized
+}
+
+// normalizedValues returns the Username, Password, Path and Fragment percent-encoded and normalized with
+// normalizePercent. Decoded values are encoded first, so decoded and raw URLs can be compared.
+func (u *URL) normalizedValues() (username, password, path, fragment string) {
+	username, password, path, fragment = u.Username, u.Password, u.Path, u.Fragment
+	if u.decoded {
+		username, password, path, fragment = u.encode()
+	}
+
+	return normalizePercent(username), normalizePercent(password), normalizePercent(path), normalizePercent(fragment)
+}
+
+// normalizePercent decodes percent-encoded unreserved characters and uppercases the hex digits of all other
+// percent-encoded characters, following RFC 3986 section 6.2.2.
+// Example: "/%7euser%2fdocs" becomes "/~user%2Fdocs"
+func normalizePercent(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' || i+2 >= len(s) || !isHex(s[i+1]) || !isHex(s[i+2]) {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		c := unhex(s[i+1])<<4 | unhex(s[i+2])
+		if isUnreserved(c) {
+			b.WriteByte(c)
+		} else {
+			b.WriteByte('%')
+			b.WriteString(strings.ToUpper(s[i+1 : i+3]))
+		}
+		i += 2
+	}
+
+	return b.String()
+}
+
+// isUnreserved reports whether c is an unreserved character of RFC 3986 section 2.3.
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+// isHex reports whether c is a hexadecimal digit.
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// unhex returns the value of a hexadecimal digit.
+func unhex(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	default:
+		return c - 'A' + 10
+	}
+}
+
+// sortQuery sorts the given query list by key and value.
+func sortQuery(query []Query) {
+	sort.SliceStable(query, func(i, j int) bool {
+		if query[i].Key != query[j].Key {
+			return query[i].Key < query[j].Key
+		}
+		return query[i].Value < query[j].Value
+	})
+}
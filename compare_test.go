@@ -0,0 +1,156 @@
+package domainer
+
+import "testing"
+
+var compareTests = []struct {
+	name     string
+	a        *URL
+	b        *URL
+	opts     CompareOptions
+	expected bool
+}{
+	{
+		"Equal URLs", &URL{Protocol: "https", Domain: "example", TLD: "com", Path: "/search"},
+		&URL{Protocol: "https", Domain: "example", TLD: "com", Path: "/search"},
+		CompareOptions{}, true,
+	},
+	{
+		"Host and protocol are case-insensitive", &URL{Protocol: "HTTPS", Subdomain: "WWW", Domain: "Example", TLD: "COM"},
+		&URL{Protocol: "https", Subdomain: "www", Domain: "example", TLD: "com"},
+		CompareOptions{}, true,
+	},
+	{
+		"FullURL is ignored", &URL{FullURL: "https://example.com", Protocol: "https", Domain: "example", TLD: "com"},
+		&URL{FullURL: "https://EXAMPLE.com", Protocol: "https", Domain: "example", TLD: "com"},
+		CompareOptions{}, true,
+	},
+	{
+		"Different paths", &URL{Domain: "example", TLD: "com", Path: "/a"},
+		&URL{Domain: "example", TLD: "com", Path: "/b"},
+		CompareOptions{}, false,
+	},
+	{
+		"Default port without option", &URL{Protocol: "https", Domain: "example", TLD: "com", Port: 443},
+		&URL{Protocol: "https", Domain: "example", TLD: "com"},
+		CompareOptions{}, false,
+	},
+	{
+		"Default port with option", &URL{Protocol: "https", Domain: "example", TLD: "com", Port: 443},
+		&URL{Protocol: "https", Domain: "example", TLD: "com"},
+		CompareOptions{IgnoreDefaultPort: true}, true,
+	},
+	{
+		"Default port of missing protocol", &URL{Domain: "example", TLD: "com", Port: 80},
+		&URL{Domain: "example", TLD: "com"},
+		CompareOptions{IgnoreDefaultPort: true}, true,
+	},
+	{
+		"Non-default port with option", &URL{Protocol: "https", Domain: "example", TLD: "com", Port: 8443},
+		&URL{Protocol: "https", Domain: "example", TLD: "com"},
+		CompareOptions{IgnoreDefaultPort: true}, false,
+	},
+	{
+		"Trailing slash without option", &URL{Domain: "example", TLD: "com", Path: "/search/"},
+		&URL{Domain: "example", TLD: "com", Path: "/search"},
+		CompareOptions{}, false,
+	},
+	{
+		"Trailing slash with option", &URL{Domain: "example", TLD: "com", Path: "/search/"},
+		&URL{Domain: "example", TLD: "com", Path: "/search"},
+		CompareOptions{IgnoreTrailingSlash: true}, true,
+	},
+	{
+		"Query order without option", &URL{Domain: "example", TLD: "com", Query: []Query{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}}},
+		&URL{Domain: "example", TLD: "com", Query: []Query{{Key: "b", Value: "2"}, {Key: "a", Value: "1"}}},
+		CompareOptions{}, false,
+	},
+	{
+		"Query order with option", &URL{Domain: "example", TLD: "com", Query: []Query{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}}},
+		&URL{Domain: "example", TLD: "com", Query: []Query{{Key: "b", Value: "2"}, {Key: "a", Value: "1"}}},
+		CompareOptions{IgnoreQueryOrder: true}, true,
+	},
+	{
+		"Credentials without option", &URL{Domain: "example", TLD: "com", Username: "user", Password: "pass"},
+		&URL{Domain: "example", TLD: "com"},
+		CompareOptions{}, false,
+	},
+	{
+		"Credentials with option", &URL{Domain: "example", TLD: "com", Username: "user", Password: "pass"},
+		&URL{Domain: "example", TLD: "com"},
+		CompareOptions{IgnoreCredentials: true}, true,
+	},
+	{
+		"Fragment with option", &URL{Domain: "example", TLD: "com", Fragment: "top"},
+		&URL{Domain: "example", TLD: "com"},
+		CompareOptions{IgnoreFragment: true}, true,
+	},
+	{
+		"Percent-encoded unreserved characters", &URL{Domain: "example", TLD: "com", Path: "/%7Euser/%61bc"},
+		&URL{Domain: "example", TLD: "com", Path: "/~user/abc"},
+		CompareOptions{}, true,
+	},
+	{
+		"Percent-encoding is case-insensitive", &URL{Domain: "example", TLD: "com", Path: "/a%2fb", Fragment: "%c3%a4"},
+		&URL{Domain: "example", TLD: "com", Path: "/a%2Fb", Fragment: "%C3%A4"},
+		CompareOptions{}, true,
+	},
+	{
+		"Percent-encoded reserved characters", &URL{Domain: "example", TLD: "com", Path: "/a%2Fb"},
+		&URL{Domain: "example", TLD: "com", Path: "/a/b"},
+		CompareOptions{}, false,
+	},
+	{
+		"Percent-encoded query", &URL{Domain: "example", TLD: "com", Query: []Query{{Key: "%7Eq", Value: "%2f"}, {Key: "a", Value: "1"}}},
+		&URL{Domain: "example", TLD: "com", Query: []Query{{Key: "a", Value: "1"}, {Key: "~q", Value: "%2F"}}},
+		CompareOptions{IgnoreQueryOrder: true}, true,
+	},
+	{
+		"Nil URL", &URL{Domain: "example", TLD: "com"}, nil,
+		CompareOptions{}, false,
+	},
+}
+
+func TestEquivalentTo(t *testing.T) {
+	for _, tt := range compareTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.EquivalentTo(tt.b, tt.opts); got != tt.expected {
+				t.Errorf("Expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestEquivalentToDecoded(t *testing.T) {
+	stubLookup(t)
+
+	raw, err := FromString("https://user%40corp@example.com/my%20docs/%7Euser#page%202")
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := FromString("https://user%40corp@example.com/my%20docs/~user#page%202", DecodeValues())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !raw.EquivalentTo(decoded, CompareOptions{}) || !decoded.EquivalentTo(raw, CompareOptions{}) {
+		t.Errorf("Expected '%s' and decoded '%s' to be equal", raw.FullURL, decoded.FullURL)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	a := &URL{Protocol: "https", Domain: "example", TLD: "com", Query: []Query{{Key: "q", Value: "1"}}}
+	b := &URL{Protocol: "https", Domain: "example", TLD: "com", Query: []Query{{Key: "q", Value: "1"}}}
+
+	if !Equal(a, b) {
+		t.Errorf("Expected URLs to be equal")
+	}
+
+	b.Port = 443
+	if Equal(a, b) {
+		t.Errorf("Expected URLs to differ")
+	}
+
+	if !Equal(nil, nil) {
+		t.Errorf("Expected nil URLs to be equal")
+	}
+}
@@ -0,0 +1,178 @@
+package domainer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// Email is a split of a given email address.
+type Email struct {
+	// Address represents the full email address this struct has been created with.
+	// Example: "user@mail.example.co.uk"
+	Address string `json:"address"`
+
+	// LocalPart represents the part before the @.
+	// Example: "user" in "user@mail.example.co.uk"
+	LocalPart string `json:"local_part"`
+
+	// Subdomain represents the subdomain of the domain.
+	// Example: "mail" in "user@mail.example.co.uk"
+	Subdomain string `json:"subdomain"`
+
+	// Hostname represents the hostname of the domain.
+	// Example: "example.co.uk" in "user@mail.example.co.uk"
+	Hostname string `json:"hostname"`
+
+	// Domain represents the domain name (or second level domain).
+	// Example: "example" in "user@mail.example.co.uk"
+	Domain string `json:"domain"`
+
+	// TLD represents the top level domain.
+	// Example: "co.uk" in "user@mail.example.co.uk"
+	TLD string `json:"tld"`
+
+	// MX represents the mail servers of the domain, ordered by preference.
+	// It is only set when parsing with LookupMX.
+	// Example: []string{"mx1.example.co.uk", "mx2.example.co.uk"}
+	MX []string `json:"mx,omitempty"`
+}
+
+// MXResolver is a Resolver that can look up mail servers. *net.Resolver and *CachingResolver satisfy it.
+type MXResolver interface {
+	// LookupMX returns the MX records of the domain.
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+// ParseEmail parses a given email address and returns an Email struct.
+// The domain is split with the same public suffix handling as FromString, and the same Option values apply.
+// The domain is only looked up if LookupMX is passed.
+func ParseEmail(address string, opts ...Option) (*Email, error) {
+	o := newOptions(opts)
+
+	// The local part may contain an @ if it is quoted, so the last one separates it from the domain
+	atIndex := strings.LastIndex(address, "@")
+	if atIndex < 1 || atIndex == len(address)-1 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidEmail, address)
+	}
+
+	e := &Email{
+		Address:   address,
+		LocalPart: address[:atIndex],
+	}
+	if len(e.LocalPart) > 64 {
+		return nil, fmt.Errorf("%w: local part of %q is longer than 64 characters", ErrInvalidEmail, address)
+	}
+	if !validLocalPart(e.LocalPart) {
+		return nil, fmt.Errorf("%w: invalid local part %q", ErrInvalidEmail, e.LocalPart)
+	}
+	if domain := address[atIndex+1:]; !validMailDomain(domain) {
+		return nil, fmt.Errorf("%w: invalid domain %q", ErrInvalidEmail, domain)
+	}
+
+	// Split the domain the same way as the host of a URL
+	u := &URL{}
+	if err := u.splitHost(address[atIndex+1:], o); err != nil {
+		return nil, err
+	}
+	e.Subdomain, e.Hostname, e.Domain, e.TLD = u.Subdomain, u.Hostname, u.Domain, u.TLD
+
+	// Get the mail servers if requested
+	if o.lookupMX {
+		if err := e.lookupMX(o.resolver, u.host()); err != nil {
+			return nil, err
+		}
+	}
+
+	return e, nil
+}
+
+// validLocalPart reports whether the local part is a dot-atom or a quoted-string of RFC 5322 section 3.4.1.
+// Non-ASCII characters are allowed, as in RFC 6531.
+// Example: "first.last+tag" and `"john doe"` are valid, "a b" and ".x" are not
+func validLocalPart(local string) bool {
+	if len(local) >= 2 && local[0] == '"' && local[len(local)-1] == '"' {
+		return validQuotedString(local[1 : len(local)-1])
+	}
+
+	if strings.HasPrefix(local, ".") || strings.HasSuffix(local, ".") || strings.Contains(local, "..") {
+		return false
+	}
+
+	for i := 0; i < len(local); i++ {
+		if local[i] != '.' && !isAtext(local[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validQuotedString reports whether the content of a quoted-string only consists of printable characters,
+// spaces and quoted pairs, which escape a quote or backslash with a backslash.
+func validQuotedString(quoted string) bool {
+	for i := 0; i < len(quoted); i++ {
+		c := quoted[i]
+		switch {
+		case c == '\\':
+			// A quoted pair escapes the next character
+			i++
+			if i == len(quoted) || quoted[i] < ' ' || quoted[i] == 0x7f {
+				return false
+			}
+		case c == '"', c < ' ', c == 0x7f:
+			return false
+		}
+	}
+
+	return true
+}
+
+// isAtext reports whether c may be used in an atom of RFC 5322 section 3.2.3.
+func isAtext(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		strings.IndexByte("!#$%&'*+-/=?^_`{|}~", c) >= 0 || c >= 0x80
+}
+
+// validMailDomain reports whether every label of the domain only consists of letters, digits and hyphens,
+// and doesn't start or end with a hyphen. Non-ASCII characters are allowed for internationalized domains.
+// Empty labels are left to the public suffix handling.
+// Example: "mail.example.com" is valid, "-example.com" and "exa mple.com" are not
+func validMailDomain(domain string) bool {
+	for _, label := range strings.Split(domain, ".") {
+		if label != "" && !validLabel(label) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// lookupMX looks up the mail servers of the domain.
+func (e *Email) lookupMX(resolver Resolver, domain string) error {
+	mxResolver, ok := resolver.(MXResolver)
+	if !ok {
+		return &DNSLookupError{Host: domain, Err: errors.New("resolver can't look up MX records")}
+	}
+
+	records, err := mxResolver.LookupMX(context.Background(), domain)
+	if err != nil {
+		return &DNSLookupError{Host: domain, Err: err}
+	}
+
+	// Sort a copy, the records may be shared with a cache
+	sorted := make([]*net.MX, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Pref < sorted[j].Pref
+	})
+
+	for _, record := range sorted {
+		e.MX = append(e.MX, strings.TrimSuffix(record.Host, "."))
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package domainer
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var emailTests = []struct {
+	name     string
+	address  string
+	opts     []Option
+	expected Email
+}{
+	{
+		"Full address", "user@mail.example.co.uk", nil, Email{
+			LocalPart: "user",
+			Subdomain: "mail",
+			Hostname:  "example.co.uk",
+			Domain:    "example",
+			TLD:       "co.uk",
+		},
+	},
+	{
+		"No subdomain", "first.last+tag@example.com", nil, Email{
+			LocalPart: "first.last+tag",
+			Hostname:  "example.com",
+			Domain:    "example",
+			TLD:       "com",
+		},
+	},
+	{
+		"Quoted local part", `"john@home"@example.com`, nil, Email{
+			LocalPart: `"john@home"`,
+			Hostname:  "example.com",
+			Domain:    "example",
+			TLD:       "com",
+		},
+	},
+	{
+		"Quoted local part with space and escaped quote", `"john \"jd\" doe"@example.com`, nil, Email{
+			LocalPart: `"john \"jd\" doe"`,
+			Hostname:  "example.com",
+			Domain:    "example",
+			TLD:       "com",
+		},
+	},
+	{
+		"Private suffix", "me@user.github.io", nil, Email{
+			LocalPart: "me",
+			Hostname:  "user.github.io",
+			Domain:    "user",
+			TLD:       "github.io",
+		},
+	},
+	{
+		"Single-label domain", "root@localhost", nil, Email{
+			LocalPart: "root",
+			Hostname:  "localhost",
+			Domain:    "localhost",
+		},
+	},
+}
+
+func TestParseEmail(t *testing.T) {
+	for _, tt := range emailTests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := ParseEmail(tt.address, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.expected.Address = tt.address
+			if !reflect.DeepEqual(*e, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, *e)
+			}
+		})
+	}
+}
+
+func TestParseEmailErrors(t *testing.T) {
+	errorTests := []struct {
+		address  string
+		expected error
+	}{
+		{"example.com", ErrInvalidEmail},
+		{"@example.com", ErrInvalidEmail},
+		{"user@", ErrInvalidEmail},
+		{"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa@example.com", ErrInvalidEmail},
+		{"a b@example.com", ErrInvalidEmail},
+		{"a@b@example.com", ErrInvalidEmail},
+		{".x@example.com", ErrInvalidEmail},
+		{"x.@example.com", ErrInvalidEmail},
+		{"x..y@example.com", ErrInvalidEmail},
+		{`"x"y"@example.com`, ErrInvalidEmail},
+		{"x@exa mple.com", ErrInvalidEmail},
+		{"x@-example.com", ErrInvalidEmail},
+		{"x@example-.com", ErrInvalidEmail},
+		{"x@example.com/path", ErrInvalidEmail},
+		{"user@co.uk", ErrPublicSuffix},
+		{"user@example..com", ErrPublicSuffix},
+	}
+
+	for _, tt := range errorTests {
+		if _, err := ParseEmail(tt.address); !errors.Is(err, tt.expected) {
+			t.Errorf("ParseEmail(%q): Expected '%v', got '%v'", tt.address, tt.expected, err)
+		}
+	}
+}
+
+func TestParseEmailMX(t *testing.T) {
+	resolver := &staticResolver{mx: []*net.MX{
+		{Host: "mx2.example.com.", Pref: 20},
+		{Host: "mx1.example.com.", Pref: 10},
+	}}
+
+	e, err := ParseEmail("user@example.com", WithResolver(resolver), LookupMX())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"mx1.example.com", "mx2.example.com"}
+	if !reflect.DeepEqual(e.MX, expected) {
+		t.Errorf("MX: Expected %q, got %q", expected, e.MX)
+	}
+
+	cached := NewCachingResolver(resolver, time.Minute)
+	for i := 0; i < 2; i++ {
+		if _, err := ParseEmail("user@example.com", WithResolver(cached), LookupMX()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if resolver.lookups != 2 {
+		t.Errorf("Expected %d lookups, got %d", 2, resolver.lookups)
+	}
+
+	if _, err := ParseEmail("user@example.com", WithResolver(&staticResolver{err: errors.New("timeout")}), LookupMX()); !errors.Is(err, ErrDNSLookup) {
+		t.Errorf("Expected '%v', got '%v'", ErrDNSLookup, err)
+	}
+}
+
+// addrOnlyResolver can't look up MX records.
+type addrOnlyResolver struct{}
+
+func (addrOnlyResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	return nil, nil
+}
+
+func (addrOnlyResolver) LookupAddr(ctx context.Context, addr string) ([]string, error) {
+	return nil, nil
+}
+
+func TestParseEmailMXUnsupported(t *testing.T) {
+	if _, err := ParseEmail("user@example.com", WithResolver(addrOnlyResolver{}), LookupMX()); !errors.Is(err, ErrDNSLookup) {
+		t.Errorf("Expected '%v', got '%v'", ErrDNSLookup, err)
+	}
+
+	if _, err := NewCachingResolver(addrOnlyResolver{}, time.Minute).LookupMX(context.Background(), "example.com"); err == nil {
+		t.Errorf("Expected error for resolver without MX support")
+	}
+}
@@ -0,0 +1,115 @@
+package domainer
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+)
+
+// JSONMode selects how a URL is encoded to JSON.
+type JSONMode int32
+
+const (
+	// JSONObject encodes a URL as an object holding all of its parts.
+	// URLs parsed with DecodeValues have "decoded":true, so they are encoded correctly after decoding them again.
+	// Example: {"full_url":"https://example.com","protocol":"https",...}
+	JSONObject JSONMode = iota
+
+	// JSONString encodes a URL as its canonical string.
+	// Example: "https://example.com"
+	JSONString
+)
+
+// jsonMode is the JSONMode used by MarshalJSON.
+var jsonMode int32
+
+// SetJSONMode sets the JSONMode used when a URL is encoded with encoding/json.
+// The default is JSONObject.
+func SetJSONMode(mode JSONMode) {
+	atomic.StoreInt32(&jsonMode, int32(mode))
+}
+
+// plainURL has the fields of URL, but none of its methods, so it is encoded with the struct tags.
+type plainURL URL
+
+// jsonURL adds the unexported state of a URL to its JSON object, so it survives a round trip.
+type jsonURL struct {
+	*plainURL
+
+	// Decoded is true if the URL has been parsed with DecodeValues.
+	Decoded bool `json:"decoded,omitempty"`
+}
+
+// MarshalJSON encodes the URL according to the JSONMode set with SetJSONMode.
+func (u URL) MarshalJSON() ([]byte, error) {
+	return u.MarshalJSONMode(JSONMode(atomic.LoadInt32(&jsonMode)))
+}
+
+// MarshalJSONMode encodes the URL according to the given JSONMode, regardless of SetJSONMode.
+func (u URL) MarshalJSONMode(mode JSONMode) ([]byte, error) {
+	if mode == JSONString {
+		return json.Marshal(u.String())
+	}
+
+	return json.Marshal(jsonURL{plainURL: (*plainURL)(&u), Decoded: u.decoded})
+}
+
+// UnmarshalJSON decodes either a JSON object holding the parts of the URL, or a JSON string
+// which is parsed like UnmarshalText.
+func (u *URL) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return u.UnmarshalText([]byte(s))
+	}
+
+	decoded := jsonURL{plainURL: (*plainURL)(u)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	u.decoded = decoded.Decoded
+
+	return nil
+}
+
+// MarshalText encodes the URL as its canonical string.
+func (u URL) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText parses the text with FromString, but doesn't look up the host, so decoding a configuration
+// doesn't depend on the network. IPAddress and IPAddresses stay empty.
+func (u *URL) UnmarshalText(text []byte) error {
+	parsed, err := FromString(string(text), withoutLookup())
+	if err != nil {
+		return err
+	}
+
+	*u = *parsed
+
+	return nil
+}
+
+// Scan implements the sql.Scanner interface, parsing a string column like UnmarshalText.
+// A NULL value leaves the URL untouched.
+func (u *URL) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case string:
+		return u.UnmarshalText([]byte(v))
+	case []byte:
+		return u.UnmarshalText(v)
+	default:
+		return fmt.Errorf("domainer: cannot scan %T into URL", src)
+	}
+}
+
+// Value implements the driver.Valuer interface, storing the URL as its canonical string.
+func (u URL) Value() (driver.Value, error) {
+	return u.String(), nil
+}
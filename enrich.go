@@ -0,0 +1,78 @@
+package domainer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// EnrichOption configures which data Enrich looks up.
+type EnrichOption func(*enrichOptions)
+
+// enrichOptions holds the configuration collected from the EnrichOption values passed to Enrich.
+type enrichOptions struct {
+	// rdap enables the RDAP lookup of the registrable domain.
+	rdap bool
+
+	// rdapServer is the base URL of the RDAP server to query.
+	rdapServer string
+
+	// client is the HTTP client used for the lookups.
+	client *http.Client
+}
+
+// WithRDAP looks up the registration data of the registrable domain via RDAP.
+// Enrich fails with ErrNoHost for relative references and with ErrPublicSuffix for IP addresses.
+func WithRDAP() EnrichOption {
+	return func(o *enrichOptions) {
+		o.rdap = true
+	}
+}
+
+// WithRDAPServer looks up the registration data via RDAP, using the given server instead of https://rdap.org.
+// Example: "https://rdap.verisign.com/com/v1"
+func WithRDAPServer(server string) EnrichOption {
+	return func(o *enrichOptions) {
+		o.rdap = true
+		o.rdapServer = server
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for the lookups.
+// By default, http.DefaultClient is used.
+func WithHTTPClient(client *http.Client) EnrichOption {
+	return func(o *enrichOptions) {
+		o.client = client
+	}
+}
+
+// Enrich looks up additional data about the URL from external services and stores it in the URL.
+// Which data is looked up is selected by the given EnrichOption values.
+// Example: u.Enrich(ctx, domainer.WithRDAP()) fills u.Registration
+func (u *URL) Enrich(ctx context.Context, opts ...EnrichOption) error {
+	o := &enrichOptions{
+		rdapServer: defaultRDAPServer,
+		client:     http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.rdap {
+		// Only registrable domains have registration data
+		if u.Relative || u.Hostname == "" {
+			return ErrNoHost
+		}
+		if u.ip() != nil {
+			return fmt.Errorf("%w: %q is an IP address", ErrPublicSuffix, u.Hostname)
+		}
+
+		registration, err := lookupRDAP(ctx, o.client, o.rdapServer, u.Hostname)
+		if err != nil {
+			return err
+		}
+		u.Registration = registration
+	}
+
+	return nil
+}
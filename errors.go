@@ -0,0 +1,102 @@
+package domainer
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNoHost is returned if the URL has no host.
+	ErrNoHost = errors.New("domainer: no host")
+
+	// ErrInvalidPort is returned if the port of the URL is not a number or out of the range 0 to 65535.
+	// The returned error is an *InvalidPortError.
+	ErrInvalidPort = errors.New("domainer: invalid port")
+
+	// ErrPublicSuffix is returned if the host can't be split into a registrable domain and a public suffix.
+	// Example: "co.uk" is a public suffix itself
+	ErrPublicSuffix = errors.New("domainer: no registrable domain")
+
+	// ErrDNSLookup is returned if the host can't be resolved.
+	// The returned error is a *DNSLookupError.
+	ErrDNSLookup = errors.New("domainer: dns lookup failed")
+
+	// ErrPrivateAddress is returned by RejectPrivate if the host resolves to an address that is not public.
+	// The returned error is a *PrivateAddressError.
+	ErrPrivateAddress = errors.New("domainer: private address")
+
+	// ErrInvalidEmail is returned by ParseEmail if the address has no valid local part or no valid domain.
+	// Example: "a b@example.com" or "user@-example.com"
+	ErrInvalidEmail = errors.New("domainer: invalid email address")
+)
+
+// InvalidPortError is returned if the port of the URL is not a number or out of the range 0 to 65535.
+type InvalidPortError struct {
+	// Input is the port as it has been given in the URL.
+	// Example: "abc" in "https://example.com:abc"
+	Input string
+
+	// Err is the error returned while converting the port.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *InvalidPortError) Error() string {
+	return fmt.Sprintf("domainer: invalid port %q", e.Input)
+}
+
+// Unwrap returns the error returned while converting the port.
+func (e *InvalidPortError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInvalidPort.
+func (e *InvalidPortError) Is(target error) bool {
+	return target == ErrInvalidPort
+}
+
+// DNSLookupError is returned if the host can't be resolved.
+type DNSLookupError struct {
+	// Host is the host that has been looked up.
+	Host string
+
+	// Err is the error returned by the resolver.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *DNSLookupError) Error() string {
+	return fmt.Sprintf("domainer: dns lookup of %q failed: %v", e.Host, e.Err)
+}
+
+// Unwrap returns the error returned by the resolver.
+func (e *DNSLookupError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrDNSLookup.
+func (e *DNSLookupError) Is(target error) bool {
+	return target == ErrDNSLookup
+}
+
+// PrivateAddressError is returned by RejectPrivate if the host resolves to an address that is not public.
+type PrivateAddressError struct {
+	// Host is the host that has been checked.
+	Host string
+
+	// IP is the address that is not public.
+	IP string
+
+	// Class is the class of the address.
+	Class AddressClass
+}
+
+// Error implements the error interface.
+func (e *PrivateAddressError) Error() string {
+	return fmt.Sprintf("domainer: %q resolves to %s address %s", e.Host, e.Class, e.IP)
+}
+
+// Is reports whether target is ErrPrivateAddress.
+func (e *PrivateAddressError) Is(target error) bool {
+	return target == ErrPrivateAddress
+}
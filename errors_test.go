@@ -0,0 +1,90 @@
+package domainer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	stubLookup(t)
+
+	errorTests := []struct {
+		name     string
+		domain   string
+		expected error
+	}{
+		{"Invalid port", "https://example.com:abc/", ErrInvalidPort},
+		{"Negative port", "https://example.com:-1/", ErrInvalidPort},
+		{"Port with plus sign", "http://example.com:+80", ErrInvalidPort},
+		{"Port out of range", "https://example.com:99999/", ErrInvalidPort},
+		{"No host", "https://", ErrNoHost},
+		{"Only port", "https://:8080/", ErrNoHost},
+		{"Public suffix", "https://co.uk", ErrPublicSuffix},
+		{"Empty label", "https://www..example.com", ErrPublicSuffix},
+		{"Opening bracket", "http://[", ErrPublicSuffix},
+		{"Closing bracket", "http://]", ErrPublicSuffix},
+		{"Single label with leading hyphen", "http://-server/", ErrPublicSuffix},
+		{"Single label with underscore", "http://build_server/", ErrPublicSuffix},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromString(tt.domain)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected '%v', got '%v'", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestInvalidPortError(t *testing.T) {
+	_, err := FromString("https://example.com:80a")
+
+	var portErr *InvalidPortError
+	if !errors.As(err, &portErr) {
+		t.Fatalf("Expected *InvalidPortError, got %T", err)
+	}
+	if portErr.Input != "80a" {
+		t.Errorf("Input: Expected '%s', got '%s'", "80a", portErr.Input)
+	}
+}
+
+func TestEmptyPort(t *testing.T) {
+	stubLookup(t)
+
+	u, err := FromString("https://example.com:/path")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Port != 0 {
+		t.Errorf("Port: Expected %d, got %d", 0, u.Port)
+	}
+	if u.EffectivePort != 443 {
+		t.Errorf("EffectivePort: Expected %d, got %d", 443, u.EffectivePort)
+	}
+	if u.Hostname != "example.com" || u.Path != "/path" {
+		t.Errorf("Expected host 'example.com' and path '/path', got '%s' and '%s'", u.Hostname, u.Path)
+	}
+}
+
+func TestDNSLookupError(t *testing.T) {
+	lookupErr := errors.New("server misbehaving")
+
+	stubResolver(t, &staticResolver{err: lookupErr})
+
+	_, err := FromString("https://www.example.com")
+	if !errors.Is(err, ErrDNSLookup) {
+		t.Errorf("Expected '%v', got '%v'", ErrDNSLookup, err)
+	}
+	if !errors.Is(err, lookupErr) {
+		t.Errorf("Expected '%v' to be wrapped, got '%v'", lookupErr, err)
+	}
+
+	var dnsErr *DNSLookupError
+	if !errors.As(err, &dnsErr) {
+		t.Fatalf("Expected *DNSLookupError, got %T", err)
+	}
+	if dnsErr.Host != "www.example.com" {
+		t.Errorf("Host: Expected '%s', got '%s'", "www.example.com", dnsErr.Host)
+	}
+}
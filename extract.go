@@ -0,0 +1,204 @@
+package domainer
+
+import (
+	"io"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ExtractOptions configures ExtractFromText and ExtractFromHTML.
+type ExtractOptions struct {
+	// Base is the URL relative references are resolved against.
+	// Without a base, relative references are skipped. In HTML, a <base href> element replaces it.
+	Base *URL
+
+	// ParseOptions are passed to FromString when parsing the extracted URLs.
+	// The hosts are not looked up by default. Pass RejectPrivate or ReverseLookup to resolve them.
+	ParseOptions []Option
+}
+
+// textURLPattern matches URLs with a scheme or starting with "www." in free text.
+var textURLPattern = regexp.MustCompile(`(?i)\b(?:[a-z][a-z0-9+.-]*://|www\.)[^\s<>"'` + "`" + `]+`)
+
+// ExtractFromText finds all URLs in the text and parses them.
+// Only URLs with a scheme, like "https://example.com", or starting with "www." are found.
+// URLs that can't be parsed are skipped, duplicates are only returned once.
+func ExtractFromText(s string, opts ExtractOptions) []*URL {
+	e := newExtractor(opts)
+
+	for _, match := range textURLPattern.FindAllString(s, -1) {
+		e.add(trimTrailingPunctuation(match))
+	}
+
+	return e.urls
+}
+
+// ExtractFromHTML finds all URLs in the href, src and srcset attributes of the HTML document and parses them.
+// URLs that can't be parsed are skipped, duplicates are only returned once.
+func ExtractFromHTML(r io.Reader, opts ExtractOptions) ([]*URL, error) {
+	e := newExtractor(opts)
+	z := html.NewTokenizer(r)
+
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			if z.Err() == io.EOF {
+				return e.urls, nil
+			}
+			return e.urls, z.Err()
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			for hasAttr {
+				var key, value []byte
+				key, value, hasAttr = z.TagAttr()
+
+				switch string(key) {
+				case "href":
+					if string(name) == "base" {
+						e.setBase(string(value))
+					} else {
+						e.add(string(value))
+					}
+				case "src":
+					e.add(string(value))
+				case "srcset":
+					for _, ref := range srcsetURLs(string(value)) {
+						e.add(ref)
+					}
+				}
+			}
+		}
+	}
+}
+
+// srcsetURLs returns the URLs of the image candidates in a srcset attribute, following the parsing rules of
+// the HTML standard. A URL runs until whitespace, so it may contain commas, and a comma only ends a candidate
+// if it follows the URL directly or is part of the descriptor outside of parentheses.
+// Example: []string{"a.jpg", "https://res.example.com/w_100,h_100/b.jpg"} for
+// "a.jpg 1x, https://res.example.com/w_100,h_100/b.jpg 2x"
+func srcsetURLs(srcset string) []string {
+	var urls []string
+
+	isSpace := func(c byte) bool {
+		return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+	}
+
+	i := 0
+	for i < len(srcset) {
+		// Skip whitespace and commas between candidates
+		for i < len(srcset) && (isSpace(srcset[i]) || srcset[i] == ',') {
+			i++
+		}
+		if i == len(srcset) {
+			break
+		}
+
+		// The URL runs until whitespace
+		start := i
+		for i < len(srcset) && !isSpace(srcset[i]) {
+			i++
+		}
+		url := srcset[start:i]
+
+		// Trailing commas end the candidate without a descriptor
+		if trimmed := strings.TrimRight(url, ","); trimmed != url {
+			if trimmed != "" {
+				urls = append(urls, trimmed)
+			}
+			continue
+		}
+		urls = append(urls, url)
+
+		// Skip the descriptor up to the next comma outside of parentheses
+		parens := false
+		for ; i < len(srcset); i++ {
+			if c := srcset[i]; c == '(' {
+				parens = true
+			} else if c == ')' {
+				parens = false
+			} else if c == ',' && !parens {
+				i++
+				break
+			}
+		}
+	}
+
+	return urls
+}
+
+// extractor collects the parsed URLs of ExtractFromText and ExtractFromHTML.
+type extractor struct {
+	base *URL
+	opts []Option
+	seen map[string]bool
+	urls []*URL
+}
+
+// newExtractor returns an extractor for the given options.
+func newExtractor(opts ExtractOptions) *extractor {
+	return &extractor{
+		base: opts.Base,
+		opts: append([]Option{withoutLookup()}, opts.ParseOptions...),
+		seen: map[string]bool{},
+	}
+}
+
+// parse parses the reference, resolving it against the base if there is one.
+// References with a scheme but without "//", like "mailto:" or "javascript:", are rejected by Resolve.
+func (e *extractor) parse(ref string) (*URL, bool) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, false
+	}
+
+	var u *URL
+	var err error
+	switch {
+	case e.base != nil:
+		u, err = e.base.Resolve(ref, e.opts...)
+	case hasScheme(ref), strings.HasPrefix(strings.ToLower(ref), "www."):
+		u, err = FromString(ref, e.opts...)
+	default:
+		return nil, false
+	}
+
+	return u, err == nil
+}
+
+// add parses the reference and collects it, unless it has been collected before.
+func (e *extractor) add(ref string) {
+	u, ok := e.parse(ref)
+	if !ok || e.seen[u.FullURL] {
+		return
+	}
+
+	e.seen[u.FullURL] = true
+	e.urls = append(e.urls, u)
+}
+
+// setBase replaces the base with the given reference.
+func (e *extractor) setBase(ref string) {
+	if u, ok := e.parse(ref); ok {
+		e.base = u
+	}
+}
+
+// trimTrailingPunctuation removes punctuation that ends the sentence around a URL.
+// Closing parentheses are only removed if they are not balanced within the URL.
+func trimTrailingPunctuation(s string) string {
+	for len(s) > 0 {
+		switch last := s[len(s)-1]; {
+		case strings.IndexByte(".,;:!?'\"", last) > -1:
+			s = s[:len(s)-1]
+		case last == ')' && strings.Count(s, ")") > strings.Count(s, "("):
+			s = s[:len(s)-1]
+		default:
+			return s
+		}
+	}
+
+	return s
+}
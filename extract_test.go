@@ -0,0 +1,177 @@
+package domainer
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fullURLs returns the FullURL of every URL.
+func fullURLs(urls []*URL) []string {
+	var result []string
+	for _, u := range urls {
+		result = append(result, u.FullURL)
+	}
+	return result
+}
+
+func TestExtractFromText(t *testing.T) {
+	stubLookup(t)
+
+	text := `Read the docs at https://docs.example.com/guide?page=2. Mirrors (see https://en.wikipedia.org/wiki/Mirror_(computing)) are
+listed on www.example.org/mirrors, or ask at "ftp://files.example.com/pub"! Duplicate: https://docs.example.com/guide?page=2`
+
+	expected := []string{
+		"https://docs.example.com/guide?page=2",
+		"https://en.wikipedia.org/wiki/Mirror_(computing)",
+		"www.example.org/mirrors",
+		"ftp://files.example.com/pub",
+	}
+
+	urls := ExtractFromText(text, ExtractOptions{})
+	if !reflect.DeepEqual(fullURLs(urls), expected) {
+		t.Errorf("Expected %q, got %q", expected, fullURLs(urls))
+	}
+	if urls[0].Subdomain != "docs" || urls[0].Query[0].Value != "2" {
+		t.Errorf("Expected parsed URL, got %+v", urls[0])
+	}
+}
+
+func TestExtractFromHTML(t *testing.T) {
+	stubLookup(t)
+
+	page := `<!DOCTYPE html>
+<html>
+<head>
+	<link rel="stylesheet" href="/css/main.css">
+	<script src="//cdn.example.net/lib.js"></script>
+</head>
+<body>
+	<a href="../about">About</a>
+	<a href="?page=2">Next</a>
+	<a href="mailto:hello@example.com">Mail</a>
+	<a href="javascript:void(0)">Nothing</a>
+	<a href="https://other.example.org/">Other</a>
+	<a href="/css/main.css">Duplicate</a>
+	<img src="logo.png" srcset="logo@2x.png 2x, /img/logo@3x.png 3x">
+</body>
+</html>`
+
+	base, err := FromString("https://www.example.com/blog/post")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	urls, err := ExtractFromHTML(strings.NewReader(page), ExtractOptions{Base: base})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"https://www.example.com/css/main.css",
+		"https://cdn.example.net/lib.js",
+		"https://www.example.com/about",
+		"https://www.example.com/blog/post?page=2",
+		"https://other.example.org/",
+		"https://www.example.com/blog/logo.png",
+		"https://www.example.com/blog/logo@2x.png",
+		"https://www.example.com/img/logo@3x.png",
+	}
+	if !reflect.DeepEqual(fullURLs(urls), expected) {
+		t.Errorf("Expected %q, got %q", expected, fullURLs(urls))
+	}
+}
+
+func TestExtractFromHTMLBaseElement(t *testing.T) {
+	stubLookup(t)
+
+	page := `<head><base href="https://static.example.com/assets/"></head><body><img src="logo.png"><a href="https://example.org/">x</a></body>`
+
+	urls, err := ExtractFromHTML(strings.NewReader(page), ExtractOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"https://static.example.com/assets/logo.png", "https://example.org/"}
+	if !reflect.DeepEqual(fullURLs(urls), expected) {
+		t.Errorf("Expected %q, got %q", expected, fullURLs(urls))
+	}
+}
+
+func TestExtractWithoutBase(t *testing.T) {
+	stubLookup(t)
+
+	page := `<a href="/relative">x</a><a href="https://example.com/absolute">y</a>`
+
+	urls, err := ExtractFromHTML(strings.NewReader(page), ExtractOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"https://example.com/absolute"}
+	if !reflect.DeepEqual(fullURLs(urls), expected) {
+		t.Errorf("Expected %q, got %q", expected, fullURLs(urls))
+	}
+}
+
+func TestExtractWithoutLookup(t *testing.T) {
+	stubResolver(t, &staticResolver{err: errors.New("no such host")})
+
+	urls := ExtractFromText("see https://docs.example.com/guide and https://www.example.org/x", ExtractOptions{})
+
+	expected := []string{"https://docs.example.com/guide", "https://www.example.org/x"}
+	if !reflect.DeepEqual(fullURLs(urls), expected) {
+		t.Errorf("Expected %q, got %q", expected, fullURLs(urls))
+	}
+
+	urls, err := ExtractFromHTML(strings.NewReader(`<a href="https://a.example.com/">a</a>`), ExtractOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(urls) != 1 || urls[0].IPAddress != "" {
+		t.Errorf("Expected one URL without IP address, got %q", fullURLs(urls))
+	}
+
+	// Options that need the addresses still look the hosts up
+	if urls := ExtractFromText("https://docs.example.com/guide", ExtractOptions{ParseOptions: []Option{RejectPrivate()}}); len(urls) != 0 {
+		t.Errorf("Expected the URL to be skipped, got %q", fullURLs(urls))
+	}
+}
+
+func TestTrimTrailingPunctuation(t *testing.T) {
+	trimTests := map[string]string{
+		"https://example.com.":        "https://example.com",
+		"https://example.com/a?b=c!,": "https://example.com/a?b=c",
+		"https://example.com/(a)":     "https://example.com/(a)",
+		"https://example.com/a)":      "https://example.com/a",
+		"https://example.com/a).":     "https://example.com/a",
+	}
+
+	for input, expected := range trimTests {
+		if got := trimTrailingPunctuation(input); got != expected {
+			t.Errorf("trimTrailingPunctuation(%q): Expected '%s', got '%s'", input, expected, got)
+		}
+	}
+}
+
+var srcsetTests = []struct {
+	srcset   string
+	expected []string
+}{
+	{"logo@2x.png 2x, /img/logo@3x.png 3x", []string{"logo@2x.png", "/img/logo@3x.png"}},
+	{"https://res.example.com/w_100,h_100/a.jpg 1x", []string{"https://res.example.com/w_100,h_100/a.jpg"}},
+	{"a.jpg 1x,https://res.example.com/w_200,h_200/b.jpg 2x", []string{"a.jpg", "https://res.example.com/w_200,h_200/b.jpg"}},
+	{"a.jpg, b.jpg 2x", []string{"a.jpg", "b.jpg"}},
+	{"a.jpg,b.jpg", []string{"a.jpg,b.jpg"}},
+	{" a.jpg (max-width: 10px, 1x), b.jpg 2x ", []string{"a.jpg", "b.jpg"}},
+	{" , ", nil},
+}
+
+func TestSrcsetURLs(t *testing.T) {
+	for _, tt := range srcsetTests {
+		if got := srcsetURLs(tt.srcset); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: Expected %q, got %q", tt.srcset, tt.expected, got)
+		}
+	}
+}
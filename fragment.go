@@ -0,0 +1,21 @@
+package domainer
+
+import "strings"
+
+// parseFragment splits a fragment that looks like a reference into FragmentPath and FragmentQuery.
+// See ParseFragment for the fragments that are split.
+func (u *URL) parseFragment() {
+	// Hashbang routes ("#!/route") are treated like regular hash routes
+	fragment := strings.TrimPrefix(u.Fragment, "!")
+	path, query, hasQuery := strings.Cut(fragment, "?")
+
+	switch {
+	case strings.HasPrefix(path, "/") || hasQuery:
+		// Hash routing, like "#/route/42?tab=billing"
+		u.FragmentPath = path
+		u.FragmentQuery = parseQuery(query, nil)
+	case strings.Contains(fragment, "="):
+		// Key-value pairs, like the media fragment "#t=30,60"
+		u.FragmentQuery = parseQuery(fragment, nil)
+	}
+}
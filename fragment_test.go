@@ -0,0 +1,74 @@
+package domainer
+
+import (
+	"reflect"
+	"testing"
+)
+
+var fragmentTests = []struct {
+	name  string
+	url   string
+	path  string
+	query []Query
+}{
+	{"Hash route", "https://app.example.com/#/route/42?tab=billing", "/route/42", []Query{{Key: "tab", Value: "billing"}}},
+	{"Hash route without query", "https://app.example.com/#/settings", "/settings", nil},
+	{"Hashbang", "https://app.example.com/#!/route/42?tab=billing&page=2", "/route/42", []Query{{Key: "tab", Value: "billing"}, {Key: "page", Value: "2"}}},
+	{"Query only", "https://app.example.com/#?tab=billing", "", []Query{{Key: "tab", Value: "billing"}}},
+	{"Media fragment", "https://example.com/video.mp4#t=30,60", "", []Query{{Key: "t", Value: "30,60"}}},
+	{"Multiple media fragments", "https://example.com/video.mp4#t=10&xywh=160,120,320,240", "", []Query{{Key: "t", Value: "10"}, {Key: "xywh", Value: "160,120,320,240"}}},
+	{"Anchor", "https://example.com/docs#section", "", nil},
+	{"No fragment", "https://example.com/docs", "", nil},
+}
+
+func TestParseFragment(t *testing.T) {
+	stubLookup(t)
+
+	for _, tt := range fragmentTests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := FromString(tt.url, ParseFragment())
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if u.FragmentPath != tt.path {
+				t.Errorf("FragmentPath: Expected '%s', got '%s'", tt.path, u.FragmentPath)
+			}
+			if !reflect.DeepEqual(u.FragmentQuery, tt.query) {
+				t.Errorf("FragmentQuery: Expected %v, got %v", tt.query, u.FragmentQuery)
+			}
+		})
+	}
+}
+
+func TestParseFragmentDisabled(t *testing.T) {
+	stubLookup(t)
+
+	u, err := FromString("https://app.example.com/#/route/42?tab=billing")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if u.FragmentPath != "" || u.FragmentQuery != nil {
+		t.Errorf("Expected no fragment parts, got '%s' and %v", u.FragmentPath, u.FragmentQuery)
+	}
+	if u.Fragment != "/route/42?tab=billing" {
+		t.Errorf("Fragment: Expected '%s', got '%s'", "/route/42?tab=billing", u.Fragment)
+	}
+}
+
+func TestParseFragmentOptions(t *testing.T) {
+	u, err := ParseReference("#/search%20results?utm_source=news&q=1", ParseFragment(), DecodeValues(), MarkTracking())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if u.FragmentPath != "/search results" {
+		t.Errorf("FragmentPath: Expected '%s', got '%s'", "/search results", u.FragmentPath)
+	}
+
+	expected := []Query{{Key: "utm_source", Value: "news", IsTracking: true}, {Key: "q", Value: "1"}}
+	if !reflect.DeepEqual(u.FragmentQuery, expected) {
+		t.Errorf("FragmentQuery: Expected %v, got %v", expected, u.FragmentQuery)
+	}
+}
@@ -3,3 +3,5 @@ module github.com/boatware/domainer
 go 1.19
 
 require golang.org/x/net v0.8.0
+
+require golang.org/x/text v0.8.0 // indirect
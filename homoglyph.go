@@ -0,0 +1,243 @@
+package domainer
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/idna"
+)
+
+// Homoglyphs describes characters in a host that look like other characters.
+type Homoglyphs struct {
+	// Host is the host with its punycode labels decoded.
+	// Example: "exаmple.com" (with a Cyrillic "а") for "https://xn--exmple-4nf.com"
+	Host string
+
+	// Scripts are the scripts used in the host, ignoring digits and punctuation.
+	// Example: []string{"Latin", "Cyrillic"}
+	Scripts []string
+
+	// MixedScripts is true if a single label of the host mixes scripts, like Latin and Cyrillic.
+	// Han, Hiragana, Katakana and Hangul may be combined with each other and with Latin.
+	MixedScripts bool
+
+	// Confusable is true if the host contains non-ASCII characters that look like ASCII characters.
+	Confusable bool
+
+	// Imitates are the targets whose domain looks like the domain of the host without being equal to it.
+	// Example: []string{"example.com"} for "https://exаmple.net"
+	Imitates []string
+}
+
+// Suspicious reports whether the host mixes scripts or imitates one of the targets.
+func (h Homoglyphs) Suspicious() bool {
+	return h.MixedScripts || len(h.Imitates) > 0
+}
+
+// homoglyphScripts are the scripts that are reported by DetectHomoglyphs.
+var homoglyphScripts = []struct {
+	name  string
+	table *unicode.RangeTable
+}{
+	{"Latin", unicode.Latin},
+	{"Cyrillic", unicode.Cyrillic},
+	{"Greek", unicode.Greek},
+	{"Armenian", unicode.Armenian},
+	{"Georgian", unicode.Georgian},
+	{"Cherokee", unicode.Cherokee},
+	{"Hebrew", unicode.Hebrew},
+	{"Arabic", unicode.Arabic},
+	{"Devanagari", unicode.Devanagari},
+	{"Thai", unicode.Thai},
+	{"Han", unicode.Han},
+	{"Hiragana", unicode.Hiragana},
+	{"Katakana", unicode.Katakana},
+	{"Hangul", unicode.Hangul},
+}
+
+// cjkScripts may be mixed with each other and with Latin within a label.
+var cjkScripts = map[string]bool{
+	"Han":      true,
+	"Hiragana": true,
+	"Katakana": true,
+	"Hangul":   true,
+}
+
+// confusables maps characters to the ASCII character they are commonly mistaken for.
+// The list covers the Cyrillic, Greek and Latin lookalikes seen in phishing domains, it is not
+// the full Unicode confusables table.
+var confusables = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'с': 'c', 'ԁ': 'd', 'е': 'e', 'ё': 'e', 'һ': 'h', 'і': 'i', 'ї': 'i',
+	'ј': 'j', 'к': 'k', 'ӏ': 'l', 'м': 'm', 'п': 'n', 'о': 'o', 'р': 'p', 'ԛ': 'q', 'г': 'r',
+	'ѕ': 's', 'т': 't', 'ѵ': 'v', 'ԝ': 'w', 'х': 'x', 'у': 'y', 'ӡ': 'z',
+
+	// Greek
+	'α': 'a', 'ϲ': 'c', 'ε': 'e', 'η': 'n', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o',
+	'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x', 'γ': 'y', 'ω': 'w',
+
+	// Latin
+	'à': 'a', 'á': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a', 'å': 'a', 'ā': 'a', 'ą': 'a', 'ɑ': 'a',
+	'ç': 'c', 'ć': 'c', 'č': 'c', 'ď': 'd', 'đ': 'd',
+	'è': 'e', 'é': 'e', 'ê': 'e', 'ë': 'e', 'ē': 'e', 'ę': 'e', 'ě': 'e',
+	'ɡ': 'g', 'ğ': 'g', 'ì': 'i', 'í': 'i', 'î': 'i', 'ï': 'i', 'ı': 'i', 'ɩ': 'i',
+	'ł': 'l', 'ľ': 'l', 'ñ': 'n', 'ń': 'n', 'ň': 'n',
+	'ò': 'o', 'ó': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o', 'ø': 'o', 'ō': 'o',
+	'ŕ': 'r', 'ř': 'r', 'ś': 's', 'š': 's', 'ş': 's', 'ť': 't',
+	'ù': 'u', 'ú': 'u', 'û': 'u', 'ü': 'u', 'ū': 'u', 'ů': 'u',
+	'ý': 'y', 'ÿ': 'y', 'ź': 'z', 'ż': 'z', 'ž': 'z',
+
+	// Digits
+	'0': 'o', '1': 'l',
+}
+
+// confusableSequences are ASCII sequences that look like a single ASCII character.
+var confusableSequences = strings.NewReplacer("rn", "m", "vv", "w")
+
+// DetectHomoglyphs decodes the punycode labels of the host and reports mixed scripts, confusable
+// characters and the targets the domain imitates.
+// A target imitates if the domain label looks the same after replacing confusable characters,
+// but is not equal to the domain label of the target.
+// Example: "https://xn--exmple-4nf.com" (with a Cyrillic "а") imitates "example.com"
+func (u *URL) DetectHomoglyphs(targets []string) Homoglyphs {
+	host := strings.ToLower(u.host())
+	if decoded, err := idna.ToUnicode(host); err == nil {
+		host = decoded
+	}
+
+	h := Homoglyphs{Host: host}
+
+	seen := map[string]bool{}
+	for _, label := range strings.Split(host, ".") {
+		scripts := labelScripts(label)
+
+		mixed := 0
+		for _, script := range scripts {
+			if !cjkScripts[script] {
+				mixed++
+			}
+			if !seen[script] {
+				seen[script] = true
+				h.Scripts = append(h.Scripts, script)
+			}
+		}
+		if mixed > 1 {
+			h.MixedScripts = true
+		}
+
+		for _, r := range label {
+			if _, ok := confusables[r]; ok && r > unicode.MaxASCII {
+				h.Confusable = true
+			}
+		}
+	}
+
+	domain := unicodeLabel(u.Domain)
+	for _, target := range targets {
+		t := &URL{}
+		if err := t.splitHost(strings.ToLower(strings.Trim(target, ".")), newOptions(nil)); err != nil {
+			continue
+		}
+
+		targetDomain := unicodeLabel(t.Domain)
+		if domain != targetDomain && skeleton(domain) == skeleton(targetDomain) {
+			h.Imitates = append(h.Imitates, target)
+		}
+	}
+
+	return h
+}
+
+// Similarity returns how much the registrable domain of the host looks like the given domain,
+// from 0 for completely different to 1 for indistinguishable. Confusable characters are replaced
+// before the edit distance of both domains is computed, so lookalikes score 1.
+// Example: 1 for "https://www.exаmple.com" and "example.com", about 0.91 for "https://exanple.com" and "example.com"
+func (u *URL) Similarity(domain string) float64 {
+	a := []rune(skeleton(unicodeLabel(u.RegistrableDomain())))
+	b := []rune(skeleton(unicodeLabel(strings.Trim(domain, "."))))
+
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - float64(editDistance(a, b))/float64(longest)
+}
+
+// labelScripts returns the scripts used in the label.
+func labelScripts(label string) []string {
+	var scripts []string
+
+	for _, script := range homoglyphScripts {
+		for _, r := range label {
+			if unicode.Is(script.table, r) {
+				scripts = append(scripts, script.name)
+				break
+			}
+		}
+	}
+
+	return scripts
+}
+
+// unicodeLabel lowercases the given label or domain and decodes its punycode labels.
+func unicodeLabel(label string) string {
+	label = strings.ToLower(label)
+	if decoded, err := idna.ToUnicode(label); err == nil {
+		return decoded
+	}
+
+	return label
+}
+
+// skeleton replaces every confusable character, so lookalike strings have the same skeleton.
+// Example: "exаmple" (with a Cyrillic "а") and "exarnple" both become "example"
+func skeleton(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if replacement, ok := confusables[r]; ok {
+			return replacement
+		}
+		return r
+	}, strings.ToLower(s))
+
+	return confusableSequences.Replace(s)
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+// min3 returns the smallest of three integers.
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+
+	return a
+}
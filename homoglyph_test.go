@@ -0,0 +1,91 @@
+package domainer
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+var homoglyphTests = []struct {
+	name       string
+	url        string
+	scripts    []string
+	mixed      bool
+	confusable bool
+	imitates   []string
+}{
+	{
+		"Cyrillic a in punycode", "https://www.xn--exmple-4nf.com",
+		[]string{"Latin", "Cyrillic"}, true, true, []string{"example.com"},
+	},
+	{
+		"Cyrillic a in unicode", "https://exаmple.net",
+		[]string{"Latin", "Cyrillic"}, true, true, []string{"example.com"},
+	},
+	{
+		"Whole-script Cyrillic", "https://xn--80ak6aa92e.com",
+		[]string{"Cyrillic", "Latin"}, false, true, []string{"apple.com"},
+	},
+	{
+		"Latin lookalikes", "https://exarnp1e.com",
+		[]string{"Latin"}, false, false, []string{"example.com"},
+	},
+	{
+		"Original domain", "https://www.example.com",
+		[]string{"Latin"}, false, false, nil,
+	},
+	{
+		"Japanese", "https://xn--r8jz45g.jp",
+		[]string{"Han", "Hiragana", "Latin"}, false, false, nil,
+	},
+}
+
+func TestDetectHomoglyphs(t *testing.T) {
+	stubLookup(t)
+
+	for _, tt := range homoglyphTests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := FromString(tt.url)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			h := u.DetectHomoglyphs([]string{"example.com", "apple.com"})
+			if !reflect.DeepEqual(h.Scripts, tt.scripts) {
+				t.Errorf("Scripts: Expected %q, got %q", tt.scripts, h.Scripts)
+			}
+			if h.MixedScripts != tt.mixed {
+				t.Errorf("MixedScripts: Expected %t, got %t", tt.mixed, h.MixedScripts)
+			}
+			if h.Confusable != tt.confusable {
+				t.Errorf("Confusable: Expected %t, got %t", tt.confusable, h.Confusable)
+			}
+			if !reflect.DeepEqual(h.Imitates, tt.imitates) {
+				t.Errorf("Imitates: Expected %q, got %q", tt.imitates, h.Imitates)
+			}
+			if h.Suspicious() != (tt.mixed || tt.imitates != nil) {
+				t.Errorf("Suspicious: Expected %t, got %t", !h.Suspicious(), h.Suspicious())
+			}
+		})
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	similarityTests := []struct {
+		url      *URL
+		domain   string
+		expected float64
+	}{
+		{&URL{Subdomain: "www", Domain: "example", TLD: "com"}, "example.com", 1},
+		{&URL{Domain: "xn--exmple-4nf", TLD: "com"}, "example.com", 1},
+		{&URL{Domain: "exanple", TLD: "com"}, "example.com", 1 - 1.0/11},
+		{&URL{Domain: "example", TLD: "com"}, "example.org", 1 - 3.0/11},
+		{&URL{Domain: "abc"}, "xyz", 0},
+	}
+
+	for _, tt := range similarityTests {
+		if got := tt.url.Similarity(tt.domain); math.Abs(got-tt.expected) > 1e-9 {
+			t.Errorf("%s: Expected %f, got %f", tt.url, tt.expected, got)
+		}
+	}
+}
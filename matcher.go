@@ -0,0 +1,273 @@
+package domainer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Matcher matches URLs against a set of compiled patterns.
+//
+// A pattern consists of an optional scheme, a host, an optional port and an optional path:
+//   - "*.example.com" matches every subdomain of example.com, but not example.com itself
+//   - "example.*" matches example.com, example.co.uk and every other public suffix
+//   - "https://*.cdn.example.com:443" only matches https on port 443
+//   - "example.com/admin/*" matches every path below /admin/
+//
+// A "*" in the path matches any sequence of characters, including slashes.
+// The patterns are stored in a trie of reversed host labels, so matching a URL only visits
+// the patterns that share its host.
+type Matcher struct {
+	// hosts indexes patterns by the reversed labels of their host.
+	hosts *matchNode
+
+	// anyTLD indexes patterns ending in ".*" by the reversed labels of their host without the TLD.
+	anyTLD *matchNode
+
+	// anyHost holds patterns with a host of "*".
+	anyHost []*matchRule
+
+	// patterns counts the compiled patterns.
+	patterns int
+}
+
+// matchNode is a node in the host label trie.
+type matchNode struct {
+	// children are the nodes for the next label.
+	children map[string]*matchNode
+
+	// exact holds rules whose host ends at this node.
+	exact []*matchRule
+
+	// subdomains holds rules matching every host below this node ("*." patterns).
+	subdomains []*matchRule
+}
+
+// matchRule is the part of a pattern that is checked after the host matched.
+type matchRule struct {
+	// pattern is the original pattern.
+	pattern string
+
+	// scheme is the required protocol, or empty for any protocol.
+	scheme string
+
+	// port is the required port, or 0 for any port.
+	port int
+
+	// path is the glob the path has to match, or empty for any path.
+	path string
+}
+
+// NewMatcher compiles the given patterns into a Matcher.
+func NewMatcher(patterns ...string) (*Matcher, error) {
+	m := &Matcher{
+		hosts:  newMatchNode(),
+		anyTLD: newMatchNode(),
+	}
+
+	for _, pattern := range patterns {
+		if err := m.Add(pattern); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// newMatchNode returns an empty trie node.
+func newMatchNode() *matchNode {
+	return &matchNode{children: map[string]*matchNode{}}
+}
+
+// Add compiles a pattern and adds it to the matcher.
+func (m *Matcher) Add(pattern string) error {
+	rule := &matchRule{pattern: pattern}
+	rest := pattern
+
+	// Split off the scheme
+	if scheme, after, found := strings.Cut(rest, "://"); found {
+		if scheme != "*" {
+			rule.scheme = strings.ToLower(scheme)
+		}
+		rest = after
+	}
+
+	// Split off the path
+	if slashIndex := strings.Index(rest, "/"); slashIndex > -1 {
+		rule.path = rest[slashIndex:]
+		rest = rest[:slashIndex]
+	}
+
+	// Split off the port
+	if colonIndex := strings.Index(rest, ":"); colonIndex > -1 {
+		port := rest[colonIndex+1:]
+		rest = rest[:colonIndex]
+
+		if port != "*" {
+			p, err := strconv.Atoi(port)
+			if err != nil {
+				return fmt.Errorf("domainer: invalid port in pattern %q: %w", pattern, err)
+			}
+			rule.port = p
+		}
+	}
+
+	host := strings.ToLower(rest)
+	if host == "" {
+		return fmt.Errorf("domainer: missing host in pattern %q", pattern)
+	}
+
+	if host == "*" {
+		m.anyHost = append(m.anyHost, rule)
+		m.patterns++
+		return nil
+	}
+
+	root := m.hosts
+	if strings.HasSuffix(host, ".*") {
+		root = m.anyTLD
+		host = strings.TrimSuffix(host, ".*")
+	}
+
+	subdomains := strings.HasPrefix(host, "*.")
+	host = strings.TrimPrefix(host, "*.")
+
+	labels := strings.Split(host, ".")
+	node := root
+	for i := len(labels) - 1; i >= 0; i-- {
+		if labels[i] == "" || labels[i] == "*" {
+			return fmt.Errorf("domainer: invalid host in pattern %q", pattern)
+		}
+
+		child, ok := node.children[labels[i]]
+		if !ok {
+			child = newMatchNode()
+			node.children[labels[i]] = child
+		}
+		node = child
+	}
+
+	if subdomains {
+		node.subdomains = append(node.subdomains, rule)
+	} else {
+		node.exact = append(node.exact, rule)
+	}
+	m.patterns++
+
+	return nil
+}
+
+// Len returns the number of compiled patterns.
+func (m *Matcher) Len() int {
+	return m.patterns
+}
+
+// Match reports whether the URL matches any of the patterns.
+func (m *Matcher) Match(u *URL) bool {
+	_, ok := m.MatchPattern(u)
+	return ok
+}
+
+// MatchPattern returns the first pattern the URL matches.
+func (m *Matcher) MatchPattern(u *URL) (string, bool) {
+	if u == nil {
+		return "", false
+	}
+
+	for _, rule := range m.anyHost {
+		if rule.match(u) {
+			return rule.pattern, true
+		}
+	}
+
+	host := strings.ToLower(u.host())
+	if rule := m.hosts.lookup(strings.Split(host, "."), u); rule != nil {
+		return rule.pattern, true
+	}
+
+	// Patterns ending in ".*" only need to match the host without its public suffix
+	if u.TLD != "" {
+		host = strings.TrimSuffix(host, "."+strings.ToLower(u.TLD))
+		if rule := m.anyTLD.lookup(strings.Split(host, "."), u); rule != nil {
+			return rule.pattern, true
+		}
+	}
+
+	return "", false
+}
+
+// lookup walks the trie along the reversed labels and returns the first rule matching the URL.
+func (n *matchNode) lookup(labels []string, u *URL) *matchRule {
+	node := n
+	for i := len(labels) - 1; i >= 0; i-- {
+		child, ok := node.children[labels[i]]
+		if !ok {
+			return nil
+		}
+		node = child
+
+		// There are labels left, so this is a subdomain of the node
+		if i > 0 {
+			for _, rule := range node.subdomains {
+				if rule.match(u) {
+					return rule
+				}
+			}
+		}
+	}
+
+	for _, rule := range node.exact {
+		if rule.match(u) {
+			return rule
+		}
+	}
+
+	return nil
+}
+
+// match checks the scheme, port and path of the URL against the rule.
+func (r *matchRule) match(u *URL) bool {
+	if r.scheme != "" && u.scheme() != r.scheme {
+		return false
+	}
+
+	if r.port != 0 && u.effectivePort() != r.port {
+		return false
+	}
+
+	if r.path != "" && !matchGlob(r.path, u.Path) {
+		return false
+	}
+
+	return true
+}
+
+// matchGlob reports whether s matches the pattern, where "*" matches any sequence of characters.
+func matchGlob(pattern, s string) bool {
+	// Remember the last star, so we can backtrack to it on a mismatch
+	star, next := -1, 0
+	p, i := 0, 0
+
+	for i < len(s) {
+		switch {
+		case p < len(pattern) && pattern[p] == '*':
+			star, next = p, i
+			p++
+		case p < len(pattern) && pattern[p] == s[i]:
+			p++
+			i++
+		case star > -1:
+			next++
+			p, i = star+1, next
+		default:
+			return false
+		}
+	}
+
+	// Only stars may be left in the pattern
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+
+	return p == len(pattern)
+}
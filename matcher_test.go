@@ -0,0 +1,97 @@
+package domainer
+
+import "testing"
+
+var matcherTests = []struct {
+	name     string
+	pattern  string
+	url      *URL
+	expected bool
+}{
+	{"Exact host", "example.com", &URL{Domain: "example", TLD: "com"}, true},
+	{"Exact host is case-insensitive", "Example.COM", &URL{Domain: "EXAMPLE", TLD: "com"}, true},
+	{"Exact host does not match subdomain", "example.com", &URL{Subdomain: "www", Domain: "example", TLD: "com"}, false},
+	{"Subdomain wildcard", "*.example.com", &URL{Subdomain: "www", Domain: "example", TLD: "com"}, true},
+	{"Subdomain wildcard with multiple labels", "*.example.com", &URL{Subdomain: "a.b", Domain: "example", TLD: "com"}, true},
+	{"Subdomain wildcard does not match domain", "*.example.com", &URL{Domain: "example", TLD: "com"}, false},
+	{"Subdomain wildcard does not match other domain", "*.example.com", &URL{Subdomain: "www", Domain: "example", TLD: "org"}, false},
+	{"TLD wildcard", "example.*", &URL{Domain: "example", TLD: "co.uk"}, true},
+	{"TLD wildcard does not match subdomain", "example.*", &URL{Subdomain: "www", Domain: "example", TLD: "com"}, false},
+	{"Subdomain and TLD wildcard", "*.example.*", &URL{Subdomain: "www", Domain: "example", TLD: "de"}, true},
+	{"Path glob", "example.*/admin/*", &URL{Domain: "example", TLD: "com", Path: "/admin/users/1"}, true},
+	{"Path glob mismatch", "example.*/admin/*", &URL{Domain: "example", TLD: "com", Path: "/public"}, false},
+	{"Path glob in the middle", "example.com/*/edit", &URL{Domain: "example", TLD: "com", Path: "/posts/1/edit"}, true},
+	{"Scheme and port", "https://*.cdn.example.com:443", &URL{Protocol: "https", Subdomain: "img.cdn", Domain: "example", TLD: "com"}, true},
+	{"Scheme mismatch", "https://*.cdn.example.com:443", &URL{Protocol: "http", Subdomain: "img.cdn", Domain: "example", TLD: "com"}, false},
+	{"Port mismatch", "https://*.cdn.example.com:443", &URL{Protocol: "https", Subdomain: "img.cdn", Domain: "example", TLD: "com", Port: 8443}, false},
+	{"Any host", "*/health", &URL{Domain: "localhost", Path: "/health"}, true},
+	{"Any scheme", "*://example.com", &URL{Protocol: "ftp", Domain: "example", TLD: "com"}, true},
+}
+
+func TestMatcher(t *testing.T) {
+	for _, tt := range matcherTests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMatcher(tt.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := m.Match(tt.url); got != tt.expected {
+				t.Errorf("Expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestMatcherPatterns(t *testing.T) {
+	m, err := NewMatcher("example.com", "*.example.org", "https://example.net/admin/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m.Len() != 3 {
+		t.Errorf("Len: Expected %d, got %d", 3, m.Len())
+	}
+
+	pattern, ok := m.MatchPattern(&URL{Protocol: "https", Domain: "example", TLD: "net", Path: "/admin/"})
+	if !ok || pattern != "https://example.net/admin/*" {
+		t.Errorf("Expected '%s', got '%s'", "https://example.net/admin/*", pattern)
+	}
+
+	if m.Match(&URL{Domain: "example", TLD: "de"}) {
+		t.Errorf("Expected no match")
+	}
+	if m.Match(nil) {
+		t.Errorf("Expected no match for nil URL")
+	}
+}
+
+func TestMatcherInvalidPatterns(t *testing.T) {
+	for _, pattern := range []string{"", "https://", "example.com:abc", "www.*.example.com", "example..com"} {
+		if _, err := NewMatcher(pattern); err == nil {
+			t.Errorf("Expected error for '%s'", pattern)
+		}
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	globTests := []struct {
+		pattern  string
+		s        string
+		expected bool
+	}{
+		{"/a/*", "/a/", true},
+		{"/a/*", "/a", false},
+		{"*", "", true},
+		{"/a/*/c", "/a/b/x/c", true},
+		{"/a/*/c", "/a/b/x/d", false},
+		{"/a*b*c", "/axxbyyc", true},
+		{"/exact", "/exact", true},
+	}
+
+	for _, tt := range globTests {
+		if got := matchGlob(tt.pattern, tt.s); got != tt.expected {
+			t.Errorf("matchGlob(%q, %q): Expected %t, got %t", tt.pattern, tt.s, tt.expected, got)
+		}
+	}
+}
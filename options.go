@@ -0,0 +1,172 @@
+package domainer
+
+// Option configures how FromString parses a URL.
+type Option func(*options)
+
+// options holds the configuration collected from the Option values passed to FromString.
+type options struct {
+	// suffixes are additional public suffixes used for this parse only.
+	suffixes []string
+
+	// suffixList replaces the public suffix list shipped with golang.org/x/net.
+	suffixList SuffixList
+
+	// suffixData is the public suffix list set with SetSuffixSource when the parse started.
+	suffixData *SuffixData
+
+	// icannSuffixesOnly ignores the private section of the public suffix list.
+	icannSuffixesOnly bool
+
+	// decodeValues stores percent-decoded values in the URL.
+	decodeValues bool
+
+	// rejectPrivate fails the parse if the host resolves to a non-public address.
+	rejectPrivate bool
+
+	// parseFragment splits fragments that look like a reference into FragmentPath and FragmentQuery.
+	parseFragment bool
+
+	// markTracking sets IsTracking on the query values used for tracking.
+	markTracking bool
+
+	// resolver looks up the IP addresses and hostnames.
+	resolver Resolver
+
+	// reverseLookup looks up the hostnames of the IP addresses.
+	reverseLookup bool
+
+	// lookupMX looks up the mail servers of an email domain.
+	lookupMX bool
+
+	// skipLookup skips looking up the IP addresses of the host, unless RejectPrivate or ReverseLookup need them.
+	// It is set with withoutLookup and by ParseReference for scheme-relative references.
+	skipLookup bool
+}
+
+// newOptions applies the given Option values to a fresh configuration.
+func newOptions(opts []Option) *options {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &o
+}
+
+// defaultOptions returns the configuration used without any Option values.
+// Unlike newOptions, it can stay on the stack of the caller.
+func defaultOptions() options {
+	return options{resolver: defaultResolver, suffixData: currentSuffixData()}
+}
+
+// WithSuffixes adds public suffixes that are only used for this parse.
+// Example: WithSuffixes("corp.internal") splits "service.corp.internal" into Domain "service" and TLD "corp.internal"
+func WithSuffixes(suffixes ...string) Option {
+	return func(o *options) {
+		for _, suffix := range suffixes {
+			o.suffixes = append(o.suffixes, normalizeSuffix(suffix))
+		}
+	}
+}
+
+// WithSuffixList replaces the public suffix list used to split the host.
+// publicsuffix.List and SuffixData satisfy the SuffixList interface.
+func WithSuffixList(list SuffixList) Option {
+	return func(o *options) {
+		o.suffixList = list
+	}
+}
+
+// ICANNSuffixesOnly ignores the private section of the public suffix list, which is honored by default.
+// Example: "user.github.io" is split into Subdomain "user", Domain "github" and TLD "io" instead of Domain "user"
+// and TLD "github.io"
+func ICANNSuffixesOnly() Option {
+	return func(o *options) {
+		o.icannSuffixesOnly = true
+	}
+}
+
+// DecodeValues stores the percent-decoded Username, Password, Path and Fragment in the URL.
+// String re-encodes them when the URL is serialized.
+// Example: "user%40corp" is stored as "user@corp"
+func DecodeValues() Option {
+	return func(o *options) {
+		o.decodeValues = true
+	}
+}
+
+// RejectPrivate fails the parse with ErrPrivateAddress if the host is or resolves to an address that is not
+// publicly routable, like a loopback, link-local or private network address.
+// This is useful to vet user-supplied URLs before requesting them.
+// Only the addresses resolved at parse time are checked. A later request may resolve the host to a different
+// address (DNS rebinding), so connect to IPAddresses instead of the host to rely on the result.
+func RejectPrivate() Option {
+	return func(o *options) {
+		o.rejectPrivate = true
+	}
+}
+
+// ParseFragment splits a fragment that looks like a reference into FragmentPath and FragmentQuery.
+// Fragments starting with "/" or a hashbang "!/", fragments containing "?" and fragments made of key-value pairs
+// are split, others are left alone.
+// Example: "#/route/42?tab=billing" has FragmentPath "/route/42" and FragmentQuery "tab=billing",
+// "#t=30,60" has FragmentQuery "t=30,60", "#section" has neither
+func ParseFragment() Option {
+	return func(o *options) {
+		o.parseFragment = true
+	}
+}
+
+// MarkTracking sets IsTracking on all query values whose key is used for tracking, including the FragmentQuery.
+// See IsTrackingParam for the keys that are recognized.
+func MarkTracking() Option {
+	return func(o *options) {
+		o.markTracking = true
+	}
+}
+
+// WithResolver sets the Resolver used to look up IP addresses and hostnames.
+// Wrap it with NewCachingResolver to share answers between parses. A nil Resolver keeps the default one.
+func WithResolver(resolver Resolver) Option {
+	return func(o *options) {
+		if resolver != nil {
+			o.resolver = resolver
+		}
+	}
+}
+
+// ReverseLookup looks up the hostnames of the IP addresses (PTR records) and stores them in ReverseHostnames.
+func ReverseLookup() Option {
+	return func(o *options) {
+		o.reverseLookup = true
+	}
+}
+
+// LookupMX looks up the mail servers of the domain when parsing an email address with ParseEmail.
+// The Resolver has to satisfy MXResolver.
+func LookupMX() Option {
+	return func(o *options) {
+		o.lookupMX = true
+	}
+}
+
+// withoutLookup sets skipLookup. It is used where the result of a parse must not depend on the network.
+func withoutLookup() Option {
+	return func(o *options) {
+		o.skipLookup = true
+	}
+}
+
+// suffixInfo identifies the public suffix list used to split the host, or returns nil for a SuffixList that is
+// not a SuffixData.
+func (o *options) suffixInfo() *SuffixInfo {
+	if o.suffixList == nil {
+		return o.suffixData.info
+	}
+
+	if data, ok := o.suffixList.(*SuffixData); ok {
+		return data.info
+	}
+
+	return nil
+}
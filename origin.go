@@ -0,0 +1,85 @@
+package domainer
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Origin is the origin of a URL as defined in RFC 6454, made up of scheme, host and port.
+// Relative references and URLs without a host have an opaque origin, which is the zero Origin.
+type Origin struct {
+	// Scheme is the protocol in lower case. URLs without a protocol have the scheme "http".
+	Scheme string `json:"scheme"`
+
+	// Host is the host in lower case, with IPv6 addresses enclosed in brackets.
+	Host string `json:"host"`
+
+	// Port is the effective port, which is the default port of the scheme if the URL has none.
+	Port int `json:"port"`
+}
+
+// IsOpaque reports whether the origin is opaque, so it is not the same as any other origin.
+func (o Origin) IsOpaque() bool {
+	return o.Host == ""
+}
+
+// String serializes the origin as described in RFC 6454 section 6.2. The port is left out if it is the
+// default port of the scheme. An opaque origin is serialized as "null".
+// Example: "https://www.example.com" or "http://localhost:8080"
+func (o Origin) String() string {
+	if o.IsOpaque() {
+		return "null"
+	}
+
+	if o.Port == 0 || o.Port == DefaultPort(o.Scheme) {
+		return o.Scheme + "://" + o.Host
+	}
+
+	return o.Scheme + "://" + o.Host + ":" + strconv.Itoa(o.Port)
+}
+
+// Origin returns the RFC 6454 origin of the URL.
+// Example: Origin{"https", "www.example.com", 443} for "https://www.example.com/search"
+func (u *URL) Origin() Origin {
+	if u.Relative || u.Domain == "" {
+		return Origin{}
+	}
+
+	return Origin{
+		Scheme: u.scheme(),
+		Host:   strings.ToLower(u.host()),
+		Port:   u.effectivePort(),
+	}
+}
+
+// SameOrigin reports whether both URLs have the same scheme, host and effective port.
+// Opaque origins are never the same origin.
+// Example: "https://example.com/a" and "https://example.com:443/b" are same-origin,
+// "https://example.com" and "https://www.example.com" are not
+func (u *URL) SameOrigin(other *URL) bool {
+	if u == nil || other == nil {
+		return false
+	}
+
+	origin := u.Origin()
+
+	return !origin.IsOpaque() && origin == other.Origin()
+}
+
+// SameSite reports whether both URLs have the same scheme and registrable domain, following the
+// "schemeful same-site" definition used for cookies. Hosts without a registrable domain, like IP addresses,
+// are only same-site with themselves. Like browsers, the private section of the public suffix list is honored,
+// unless the URLs are parsed with ICANNSuffixesOnly.
+// Example: "https://www.example.co.uk" and "https://api.example.co.uk" are same-site,
+// "https://www.example.co.uk" and "http://www.example.co.uk" are not
+func (u *URL) SameSite(other *URL) bool {
+	if u == nil || other == nil {
+		return false
+	}
+
+	if u.Origin().IsOpaque() || other.Origin().IsOpaque() {
+		return false
+	}
+
+	return u.scheme() == other.scheme() && strings.EqualFold(u.RegistrableDomain(), other.RegistrableDomain())
+}
@@ -0,0 +1,115 @@
+package domainer
+
+import "testing"
+
+var originTests = []struct {
+	name     string
+	url      *URL
+	expected Origin
+	str      string
+}{
+	{
+		"Default port", &URL{Protocol: "https", Subdomain: "www", Domain: "example", TLD: "com", Path: "/search"},
+		Origin{"https", "www.example.com", 443}, "https://www.example.com",
+	},
+	{
+		"Explicit default port", &URL{Protocol: "HTTPS", Domain: "Example", TLD: "COM", Port: 443},
+		Origin{"https", "example.com", 443}, "https://example.com",
+	},
+	{
+		"Custom port", &URL{Protocol: "http", Domain: "localhost", Port: 8080},
+		Origin{"http", "localhost", 8080}, "http://localhost:8080",
+	},
+	{
+		"Missing protocol", &URL{Domain: "example", TLD: "com"},
+		Origin{"http", "example.com", 80}, "http://example.com",
+	},
+	{
+		"IPv6", &URL{Protocol: "https", Domain: "::1", Port: 8443},
+		Origin{"https", "[::1]", 8443}, "https://[::1]:8443",
+	},
+	{
+		"Relative reference", &URL{Path: "/search", Relative: true},
+		Origin{}, "null",
+	},
+}
+
+func TestOrigin(t *testing.T) {
+	for _, tt := range originTests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := tt.url.Origin()
+			if origin != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, origin)
+			}
+			if origin.String() != tt.str {
+				t.Errorf("String: Expected '%s', got '%s'", tt.str, origin.String())
+			}
+		})
+	}
+}
+
+var sameOriginTests = []struct {
+	a      string
+	b      string
+	origin bool
+	site   bool
+}{
+	{"https://example.com/a", "https://example.com:443/b", true, true},
+	{"https://www.example.com", "https://example.com", false, true},
+	{"https://www.example.co.uk", "https://api.example.co.uk", false, true},
+	{"https://www.example.co.uk", "http://www.example.co.uk", false, false},
+	{"https://example.co.uk", "https://other.co.uk", false, false},
+	{"https://example.com:8443", "https://example.com", false, true},
+	{"https://user.github.io", "https://other.github.io", false, false},
+	{"http://127.0.0.1", "http://127.0.0.1:8080", false, true},
+	{"http://127.0.0.1", "http://127.0.0.2", false, false},
+}
+
+func TestSameOriginAndSite(t *testing.T) {
+	stubLookup(t)
+
+	for _, tt := range sameOriginTests {
+		a, err := FromString(tt.a)
+		if err != nil {
+			t.Fatalf("%s: Unexpected error: %s", tt.a, err)
+		}
+		b, err := FromString(tt.b)
+		if err != nil {
+			t.Fatalf("%s: Unexpected error: %s", tt.b, err)
+		}
+
+		if got := a.SameOrigin(b); got != tt.origin {
+			t.Errorf("SameOrigin(%s, %s): Expected %t, got %t", tt.a, tt.b, tt.origin, got)
+		}
+		if got := a.SameSite(b); got != tt.site {
+			t.Errorf("SameSite(%s, %s): Expected %t, got %t", tt.a, tt.b, tt.site, got)
+		}
+	}
+
+	// Without the private section of the list, github.io is not a public suffix
+	a, _ := FromString("https://user.github.io", ICANNSuffixesOnly())
+	b, _ := FromString("https://other.github.io", ICANNSuffixesOnly())
+	if !a.SameSite(b) {
+		t.Errorf("Expected user.github.io and other.github.io to be same-site with ICANNSuffixesOnly")
+	}
+
+	relative := &URL{Path: "/a", Relative: true}
+	if relative.SameOrigin(relative) || relative.SameSite(relative) {
+		t.Errorf("Expected relative references to be neither same-origin nor same-site")
+	}
+}
+
+func TestRegistrableDomain(t *testing.T) {
+	for _, tt := range []struct {
+		url      *URL
+		expected string
+	}{
+		{&URL{Subdomain: "www", Domain: "example", TLD: "co.uk"}, "example.co.uk"},
+		{&URL{Domain: "localhost"}, "localhost"},
+		{&URL{Domain: "127.0.0.1"}, "127.0.0.1"},
+	} {
+		if got := tt.url.RegistrableDomain(); got != tt.expected {
+			t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package domainer
+
+import (
+	"path"
+	"strings"
+)
+
+// PathSegments returns the percent-decoded segments of the path, without leading and trailing slashes.
+// "." and ".." segments are resolved.
+// Example: []string{"docs", "hello world"} in "https://example.com/docs/old/../hello%20world/"
+func (u *URL) PathSegments() []string {
+	p := strings.Trim(u.Path, "/")
+	if p == "" {
+		return nil
+	}
+
+	raw := strings.Split(p, "/")
+	segments := make([]string, 0, len(raw))
+
+	for _, segment := range raw {
+		switch segment = u.unescape(segment); segment {
+		case ".":
+			// The current directory doesn't add a segment
+		case "..":
+			// The parent directory removes the previous segment
+			if len(segments) > 0 {
+				segments = segments[:len(segments)-1]
+			}
+		default:
+			segments = append(segments, segment)
+		}
+	}
+
+	if len(segments) == 0 {
+		return nil
+	}
+
+	return segments
+}
+
+// HasTrailingSlash reports whether the path ends with a slash, after resolving "." and ".." segments.
+// Example: true in "https://example.com/docs/"
+func (u *URL) HasTrailingSlash() bool {
+	return strings.HasSuffix(removeDotSegments(u.Path), "/")
+}
+
+// File returns the percent-decoded last segment of the path, or an empty string if the path ends with a slash.
+// Example: "report 2023.pdf" in "https://example.com/docs/report%202023.pdf"
+func (u *URL) File() string {
+	if u.HasTrailingSlash() {
+		return ""
+	}
+
+	segments := u.PathSegments()
+	if len(segments) == 0 {
+		return ""
+	}
+
+	return segments[len(segments)-1]
+}
+
+// Extension returns the extension of the file, including the dot.
+// Example: ".pdf" in "https://example.com/docs/report.pdf"
+func (u *URL) Extension() string {
+	return path.Ext(u.File())
+}
@@ -0,0 +1,48 @@
+package domainer
+
+import (
+	"reflect"
+	"testing"
+)
+
+var pathTests = []struct {
+	name          string
+	path          string
+	segments      []string
+	file          string
+	extension     string
+	trailingSlash bool
+}{
+	{"Empty path", "", nil, "", "", false},
+	{"Root", "/", nil, "", "", true},
+	{"File", "/docs/report.pdf", []string{"docs", "report.pdf"}, "report.pdf", ".pdf", false},
+	{"Directory", "/docs/reports/", []string{"docs", "reports"}, "", "", true},
+	{"No extension", "/docs/readme", []string{"docs", "readme"}, "readme", "", false},
+	{"Multiple extensions", "/archive.tar.gz", []string{"archive.tar.gz"}, "archive.tar.gz", ".gz", false},
+	{"Encoded segments", "/my%20docs/report%202023.pdf", []string{"my docs", "report 2023.pdf"}, "report 2023.pdf", ".pdf", false},
+	{"Encoded slash", "/a%2Fb/c", []string{"a/b", "c"}, "c", "", false},
+	{"Dot segments", "/a/./b/../c.txt", []string{"a", "c.txt"}, "c.txt", ".txt", false},
+	{"Trailing dot segment", "/a/b/..", []string{"a"}, "", "", true},
+	{"Too many parent segments", "/../../a", []string{"a"}, "a", "", false},
+}
+
+func TestPath(t *testing.T) {
+	for _, tt := range pathTests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &URL{Domain: "example", TLD: "com", Path: tt.path}
+
+			if !reflect.DeepEqual(u.PathSegments(), tt.segments) {
+				t.Errorf("PathSegments: Expected %q, got %q", tt.segments, u.PathSegments())
+			}
+			if u.File() != tt.file {
+				t.Errorf("File: Expected '%s', got '%s'", tt.file, u.File())
+			}
+			if u.Extension() != tt.extension {
+				t.Errorf("Extension: Expected '%s', got '%s'", tt.extension, u.Extension())
+			}
+			if u.HasTrailingSlash() != tt.trailingSlash {
+				t.Errorf("HasTrailingSlash: Expected %t, got %t", tt.trailingSlash, u.HasTrailingSlash())
+			}
+		})
+	}
+}
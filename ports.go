@@ -0,0 +1,80 @@
+package domainer
+
+import (
+	"strings"
+	"sync"
+)
+
+var (
+	// defaultPorts maps a protocol to the port that is used when none is given explicitly.
+	defaultPorts = map[string]int{
+		"ftp":      21,
+		"ssh":      22,
+		"sftp":     22,
+		"telnet":   23,
+		"smtp":     25,
+		"gopher":   70,
+		"http":     80,
+		"ws":       80,
+		"pop3":     110,
+		"nntp":     119,
+		"imap":     143,
+		"ldap":     389,
+		"https":    443,
+		"wss":      443,
+		"smtps":    465,
+		"ldaps":    636,
+		"imaps":    993,
+		"pop3s":    995,
+		"mysql":    3306,
+		"postgres": 5432,
+		"amqp":     5672,
+		"redis":    6379,
+		"mongodb":  27017,
+	}
+
+	// defaultPortsMu guards defaultPorts.
+	defaultPortsMu sync.RWMutex
+)
+
+// RegisterSchemePort sets the default port of a protocol, which is used if a URL doesn't specify one.
+// Example: RegisterSchemePort("rtsp", 554)
+func RegisterSchemePort(scheme string, port int) {
+	defaultPortsMu.Lock()
+	defer defaultPortsMu.Unlock()
+
+	defaultPorts[strings.ToLower(scheme)] = port
+}
+
+// DefaultPort returns the default port of the given protocol, or 0 if it is unknown.
+func DefaultPort(scheme string) int {
+	defaultPortsMu.RLock()
+	defer defaultPortsMu.RUnlock()
+
+	return defaultPorts[strings.ToLower(scheme)]
+}
+
+// IsDefaultPort reports whether the URL uses the default port of its protocol,
+// either because no port is given or because it is given explicitly.
+func (u *URL) IsDefaultPort() bool {
+	return u.Port == 0 || u.Port == DefaultPort(u.scheme())
+}
+
+// effectivePort returns the port of the URL, falling back to the default port of its protocol.
+func (u *URL) effectivePort() int {
+	if u.Port != 0 {
+		return u.Port
+	}
+
+	return DefaultPort(u.scheme())
+}
+
+// scheme returns the protocol of the URL in lower case.
+// If the protocol is not set, we assume it's http.
+func (u *URL) scheme() string {
+	if u.Protocol == "" {
+		return "http"
+	}
+
+	return strings.ToLower(u.Protocol)
+}
@@ -0,0 +1,65 @@
+package domainer
+
+import "testing"
+
+var portTests = []struct {
+	name          string
+	domain        string
+	port          int
+	effectivePort int
+	isDefault     bool
+}{
+	{"http without port", "http://example.com", 0, 80, true},
+	{"https without port", "https://example.com", 0, 443, true},
+	{"https with default port", "https://example.com:443", 443, 443, true},
+	{"https with other port", "https://example.com:8443", 8443, 8443, false},
+	{"ftp without port", "ftp://example.com", 0, 21, true},
+	{"No protocol", "example.com", 0, 80, true},
+	{"Unknown protocol", "foo://example.com", 0, 0, true},
+}
+
+func TestEffectivePort(t *testing.T) {
+	stubLookup(t)
+
+	for _, tt := range portTests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := FromString(tt.domain)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if u.Port != tt.port {
+				t.Errorf("Port: Expected %d, got %d", tt.port, u.Port)
+			}
+			if u.EffectivePort != tt.effectivePort {
+				t.Errorf("EffectivePort: Expected %d, got %d", tt.effectivePort, u.EffectivePort)
+			}
+			if u.IsDefaultPort() != tt.isDefault {
+				t.Errorf("IsDefaultPort: Expected %t, got %t", tt.isDefault, u.IsDefaultPort())
+			}
+		})
+	}
+}
+
+func TestRegisterSchemePort(t *testing.T) {
+	stubLookup(t)
+
+	RegisterSchemePort("RTSP", 554)
+	t.Cleanup(func() {
+		defaultPortsMu.Lock()
+		delete(defaultPorts, "rtsp")
+		defaultPortsMu.Unlock()
+	})
+
+	u, err := FromString("rtsp://camera.example.com/stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.EffectivePort != 554 {
+		t.Errorf("EffectivePort: Expected %d, got %d", 554, u.EffectivePort)
+	}
+	if DefaultPort("rtsp") != 554 {
+		t.Errorf("DefaultPort: Expected %d, got %d", 554, DefaultPort("rtsp"))
+	}
+}
@@ -0,0 +1,152 @@
+package domainer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ProberOptions configures a Prober.
+type ProberOptions struct {
+	// Timeout limits how long each step of a probe may take. No timeout is used if it is 0.
+	Timeout time.Duration
+
+	// HTTP sends a HEAD request after the TCP connection has been established.
+	// Only URLs with the http or https protocol are requested.
+	HTTP bool
+
+	// Client is the HTTP client used for the HEAD request. By default, http.DefaultClient is used,
+	// which follows up to 10 redirects.
+	Client *http.Client
+
+	// ParseOptions are passed to FromString when parsing the final location after redirects.
+	ParseOptions []Option
+}
+
+// ProbeResult is the outcome of probing a URL.
+type ProbeResult struct {
+	// Reachable is true if a TCP connection to the host could be established.
+	Reachable bool `json:"reachable"`
+
+	// DialLatency is the time it took to establish the TCP connection.
+	DialLatency time.Duration `json:"dial_latency"`
+
+	// HTTPLatency is the time it took to receive the response to the HEAD request, including redirects.
+	HTTPLatency time.Duration `json:"http_latency"`
+
+	// StatusCode is the status code of the final response to the HEAD request.
+	// Example: 200
+	StatusCode int `json:"status_code"`
+
+	// Server is the Server header of the final response.
+	// Example: "nginx/1.25.3"
+	Server string `json:"server"`
+
+	// Location is the URL the HEAD request has been redirected to, or nil if there was no redirect.
+	Location *URL `json:"location,omitempty"`
+
+	// Err is the error that stopped the probe, if any.
+	Err error `json:"-"`
+}
+
+// Prober checks whether URLs are reachable.
+type Prober struct {
+	opts ProberOptions
+}
+
+// NewProber returns a Prober using the given options.
+func NewProber(opts ProberOptions) *Prober {
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+
+	return &Prober{opts: opts}
+}
+
+// Probe dials the effective port of the host and, if enabled, sends a HEAD request to the URL.
+// If the URL has an IPAddress, that address is dialed instead of the host.
+// Relative references and URLs without a host fail with ErrNoHost, resolve them first.
+func (p *Prober) Probe(ctx context.Context, u *URL) *ProbeResult {
+	result := &ProbeResult{}
+
+	// An empty host would dial the local machine
+	if u.Relative || u.host() == "" {
+		result.Err = ErrNoHost
+		return result
+	}
+
+	port := u.effectivePort()
+	if port == 0 {
+		result.Err = fmt.Errorf("domainer: no default port for protocol %q", u.scheme())
+		return result
+	}
+
+	address := u.host()
+	if u.IPAddress != "" {
+		address = u.IPAddress
+	} else if ip := u.ip(); ip != nil {
+		address = u.Domain
+	}
+
+	dialCtx, cancel := p.withTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
+	conn, err := (&net.Dialer{}).DialContext(dialCtx, "tcp", net.JoinHostPort(address, strconv.Itoa(port)))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.DialLatency = time.Since(start)
+	result.Reachable = true
+	_ = conn.Close()
+
+	if !p.opts.HTTP || (u.scheme() != "http" && u.scheme() != "https") {
+		return result
+	}
+
+	requestCtx, cancelRequest := p.withTimeout(ctx)
+	defer cancelRequest()
+
+	target := u.String()
+	if u.Protocol == "" {
+		target = "http://" + target
+	}
+
+	req, err := http.NewRequestWithContext(requestCtx, http.MethodHead, target, nil)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	start = time.Now()
+	resp, err := p.opts.Client.Do(req)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.HTTPLatency = time.Since(start)
+	_ = resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	result.Server = resp.Header.Get("Server")
+
+	// The request of the response is the last one sent, so it differs if we have been redirected
+	if final := resp.Request.URL.String(); final != req.URL.String() {
+		result.Location, result.Err = FromString(final, p.opts.ParseOptions...)
+	}
+
+	return result
+}
+
+// withTimeout derives a context that is canceled after the configured timeout.
+func (p *Prober) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.opts.Timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, p.opts.Timeout)
+}
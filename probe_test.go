@@ -0,0 +1,123 @@
+package domainer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newProbeServer(t *testing.T) (*httptest.Server, int) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server", "domainer-test")
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+			return
+		}
+		if r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	port, err := strconv.Atoi(server.URL[strings.LastIndex(server.URL, ":")+1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return server, port
+}
+
+func TestProbe(t *testing.T) {
+	_, port := newProbeServer(t)
+
+	p := NewProber(ProberOptions{Timeout: time.Second, HTTP: true})
+	result := p.Probe(context.Background(), &URL{Protocol: "http", Domain: "127.0.0.1", Port: port, Path: "/health"})
+
+	if result.Err != nil {
+		t.Fatal(result.Err)
+	}
+	if !result.Reachable {
+		t.Errorf("Expected URL to be reachable")
+	}
+	if result.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode: Expected %d, got %d", http.StatusOK, result.StatusCode)
+	}
+	if result.Server != "domainer-test" {
+		t.Errorf("Server: Expected '%s', got '%s'", "domainer-test", result.Server)
+	}
+	if result.Location != nil {
+		t.Errorf("Expected no location, got '%s'", result.Location.FullURL)
+	}
+}
+
+func TestProbeRedirect(t *testing.T) {
+	_, port := newProbeServer(t)
+
+	p := NewProber(ProberOptions{HTTP: true})
+	result := p.Probe(context.Background(), &URL{Protocol: "http", Domain: "127.0.0.1", Port: port, Path: "/old"})
+
+	if result.Err != nil {
+		t.Fatal(result.Err)
+	}
+	if result.Location == nil {
+		t.Fatal("Expected location to be set")
+	}
+	if result.Location.Path != "/new" || result.Location.Port != port {
+		t.Errorf("Location: Expected path '/new' on port %d, got '%s'", port, result.Location.FullURL)
+	}
+}
+
+func TestProbeTCPOnly(t *testing.T) {
+	_, port := newProbeServer(t)
+
+	p := NewProber(ProberOptions{})
+	result := p.Probe(context.Background(), &URL{Protocol: "http", Domain: "example", TLD: "com", IPAddress: "127.0.0.1", Port: port})
+
+	if result.Err != nil {
+		t.Fatal(result.Err)
+	}
+	if !result.Reachable || result.StatusCode != 0 {
+		t.Errorf("Expected reachable URL without status code, got %+v", result)
+	}
+}
+
+func TestProbeUnreachable(t *testing.T) {
+	server, port := newProbeServer(t)
+	server.Close()
+
+	p := NewProber(ProberOptions{Timeout: time.Second, HTTP: true})
+	result := p.Probe(context.Background(), &URL{Protocol: "http", Domain: "127.0.0.1", Port: port})
+
+	if result.Err == nil || result.Reachable {
+		t.Errorf("Expected unreachable URL, got %+v", result)
+	}
+
+	result = p.Probe(context.Background(), &URL{Protocol: "foo", Domain: "127.0.0.1"})
+	if result.Err == nil {
+		t.Errorf("Expected error for unknown port")
+	}
+}
+
+func TestProbeNoHost(t *testing.T) {
+	_, port := newProbeServer(t)
+
+	reference, err := ParseReference("?page=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewProber(ProberOptions{Timeout: time.Second})
+	for _, u := range []*URL{reference, {}, {Protocol: "http", Port: port}} {
+		result := p.Probe(context.Background(), u)
+		if !errors.Is(result.Err, ErrNoHost) || result.Reachable {
+			t.Errorf("Expected '%v' for %+v, got %+v", ErrNoHost, *u, result)
+		}
+	}
+}
@@ -0,0 +1,383 @@
+package domainer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/idna"
+	"golang.org/x/net/publicsuffix"
+)
+
+// SuffixInfo identifies the version of a public suffix list.
+type SuffixInfo struct {
+	// Source is where the list has been loaded from.
+	// Example: "golang.org/x/net/publicsuffix", "embedded", "/etc/psl/public_suffix_list.dat" or
+	// "https://publicsuffix.org/list/public_suffix_list.dat"
+	Source string `json:"source"`
+
+	// Version is the version of the list, taken from the "// VERSION:" line of the list or the git revision
+	// of the list shipped with golang.org/x/net.
+	// Example: "2024-06-13_08-48-10_UTC"
+	Version string `json:"version"`
+
+	// Date is the date the list has been published. If the list has no version, the modification date of the
+	// file or the Last-Modified header of the response is used.
+	Date time.Time `json:"date"`
+
+	// Checksum is the hex-encoded SHA-256 of the list, empty for the list shipped with golang.org/x/net.
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// SuffixData is a parsed public suffix list. It satisfies SuffixList, so it can be pinned for a single
+// parse with WithSuffixList, or for every parse with SetSuffixSource.
+type SuffixData struct {
+	// info identifies the list. It is shared with every URL parsed with the list.
+	info *SuffixInfo
+
+	// rules maps the rules of the list to whether they are in the ICANN section.
+	// Wildcard rules keep their "*." prefix and exception rules their "!" prefix.
+	// A nil map uses the list shipped with golang.org/x/net.
+	rules map[string]bool
+}
+
+// builtinSuffixData is the list shipped with golang.org/x/net.
+var builtinSuffixData = &SuffixData{info: builtinSuffixInfo()}
+
+// builtinSuffixInfo takes the git revision and date from the version string of golang.org/x/net/publicsuffix.
+// Example: "publicsuffix.org's public_suffix_list.dat, git revision e248cbc9... (2022-11-15T18:02:38Z)"
+func builtinSuffixInfo() *SuffixInfo {
+	info := &SuffixInfo{Source: "golang.org/x/net/publicsuffix", Version: publicsuffix.List.String()}
+
+	if _, revision, found := strings.Cut(info.Version, "git revision "); found {
+		revision, date, _ := strings.Cut(revision, " (")
+		info.Version = revision
+		info.Date, _ = time.Parse(time.RFC3339, strings.TrimSuffix(date, ")"))
+	}
+
+	return info
+}
+
+// ParseSuffixData parses a public suffix list in the format of https://publicsuffix.org/list/public_suffix_list.dat.
+func ParseSuffixData(r io.Reader) (*SuffixData, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSuffixData(data, "", time.Time{})
+}
+
+// parseSuffixData parses the list and records where it has been loaded from.
+// The date is only used if the list has no "// VERSION:" line.
+func parseSuffixData(data []byte, source string, date time.Time) (*SuffixData, error) {
+	checksum := sha256.Sum256(data)
+	d := &SuffixData{
+		info:  &SuffixInfo{Source: source, Date: date, Checksum: hex.EncodeToString(checksum[:])},
+		rules: map[string]bool{},
+	}
+
+	icann := true
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "//") {
+			comment := strings.TrimSpace(strings.TrimPrefix(line, "//"))
+			switch {
+			case strings.HasPrefix(comment, "===BEGIN ICANN DOMAINS==="):
+				icann = true
+			case strings.HasPrefix(comment, "===BEGIN PRIVATE DOMAINS==="):
+				icann = false
+			case strings.HasPrefix(comment, "VERSION:"):
+				d.info.Version = strings.TrimSpace(strings.TrimPrefix(comment, "VERSION:"))
+				if published, err := time.Parse("2006-01-02_15-04-05_MST", d.info.Version); err == nil {
+					d.info.Date = published
+				}
+			}
+			continue
+		}
+
+		// Only the first word of a line is part of the rule
+		if fields := strings.Fields(line); len(fields) > 0 {
+			d.rules[suffixKey(fields[0])] = icann
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(d.rules) == 0 {
+		return nil, fmt.Errorf("%w: no rules in suffix list %q", ErrPublicSuffix, source)
+	}
+
+	return d, nil
+}
+
+// suffixKey lowercases a rule or domain and decodes its punycode labels, so both forms of a name match.
+func suffixKey(name string) string {
+	name = strings.ToLower(name)
+	if decoded, err := idna.ToUnicode(name); err == nil {
+		return decoded
+	}
+
+	return name
+}
+
+// Info identifies the version of the list.
+func (d *SuffixData) Info() SuffixInfo {
+	return *d.info
+}
+
+// PublicSuffix returns the public suffix of the given domain, including suffixes from the private section.
+func (d *SuffixData) PublicSuffix(domain string) string {
+	suffix, _ := d.lookup(domain)
+	return suffix
+}
+
+// lookup returns the public suffix of the domain following the algorithm of https://publicsuffix.org/list/,
+// and whether it is in the ICANN section of the list.
+func (d *SuffixData) lookup(domain string) (string, bool) {
+	if d.rules == nil {
+		return publicsuffix.PublicSuffix(domain)
+	}
+
+	labels := strings.Split(domain, ".")
+	keys := strings.Split(suffixKey(domain), ".")
+	if len(keys) != len(labels) {
+		keys = strings.Split(strings.ToLower(domain), ".")
+	}
+
+	for i := range keys {
+		candidate := strings.Join(keys[i:], ".")
+
+		// An exception rule makes its parent the public suffix
+		if icann, ok := d.rules["!"+candidate]; ok {
+			return strings.Join(labels[i+1:], "."), icann
+		}
+
+		if icann, ok := d.rules[candidate]; ok {
+			return strings.Join(labels[i:], "."), icann
+		}
+
+		if i+1 < len(keys) {
+			if icann, ok := d.rules["*."+strings.Join(keys[i+1:], ".")]; ok {
+				return strings.Join(labels[i:], "."), icann
+			}
+		}
+	}
+
+	// Unlisted names fall back to their last label, as if the list had a "*" rule
+	return labels[len(labels)-1], false
+}
+
+// SuffixSource loads a public suffix list.
+type SuffixSource interface {
+	// LoadSuffixes loads the current version of the list.
+	LoadSuffixes(ctx context.Context) (*SuffixData, error)
+}
+
+// suffixSourceFunc adapts a function to the SuffixSource interface.
+type suffixSourceFunc func(ctx context.Context) (*SuffixData, error)
+
+// LoadSuffixes calls the function.
+func (f suffixSourceFunc) LoadSuffixes(ctx context.Context) (*SuffixData, error) {
+	return f(ctx)
+}
+
+// BuiltinSuffixes is the list shipped with golang.org/x/net, which is used by default.
+// Its version depends on the version of golang.org/x/net the program is built with.
+func BuiltinSuffixes() SuffixSource {
+	return suffixSourceFunc(func(context.Context) (*SuffixData, error) {
+		return builtinSuffixData, nil
+	})
+}
+
+// SuffixBytes parses a snapshot of the list, usually one embedded into the program with go:embed.
+func SuffixBytes(data []byte) SuffixSource {
+	return suffixSourceFunc(func(context.Context) (*SuffixData, error) {
+		return parseSuffixData(data, "embedded", time.Time{})
+	})
+}
+
+// SuffixFile reads the list from a local file every time it is loaded.
+func SuffixFile(path string) SuffixSource {
+	return suffixSourceFunc(func(context.Context) (*SuffixData, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var modified time.Time
+		if stat, err := os.Stat(path); err == nil {
+			modified = stat.ModTime().UTC()
+		}
+
+		return parseSuffixData(data, path, modified)
+	})
+}
+
+// defaultSuffixTimeout limits loading a public suffix list if no other timeout is given.
+const defaultSuffixTimeout = 30 * time.Second
+
+// SuffixURL downloads the list every time it is loaded.
+// If client is nil, a client that gives up after 30 seconds is used.
+// Example: SuffixURL("https://publicsuffix.org/list/public_suffix_list.dat", nil)
+func SuffixURL(url string, client *http.Client) SuffixSource {
+	if client == nil {
+		client = &http.Client{Timeout: defaultSuffixTimeout}
+	}
+
+	return suffixSourceFunc(func(ctx context.Context) (*SuffixData, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("domainer: downloading suffix list %q failed with status %s", url, resp.Status)
+		}
+
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		modified, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+
+		return parseSuffixData(data, url, modified)
+	})
+}
+
+// SuffixSourceOptions configures SetSuffixSource.
+type SuffixSourceOptions struct {
+	// Refresh reloads the source in this interval. The source is only loaded once if it is 0.
+	// A failed reload keeps the previous list.
+	Refresh time.Duration
+
+	// OnUpdate is called after every reload with the info of the new list, or with the error if the reload failed.
+	OnUpdate func(info *SuffixInfo, err error)
+
+	// Timeout limits every load of the source, including the first one. It is 30 seconds if it is 0.
+	Timeout time.Duration
+}
+
+// timeout returns the time a load of the source may take.
+func (o SuffixSourceOptions) timeout() time.Duration {
+	if o.Timeout > 0 {
+		return o.Timeout
+	}
+
+	return defaultSuffixTimeout
+}
+
+var (
+	// activeSuffixes is the list used by every parse without WithSuffixList.
+	activeSuffixes = builtinSuffixData
+
+	// stopRefresh stops the goroutine reloading the active source, if there is one.
+	stopRefresh chan struct{}
+
+	// activeSuffixesMu guards activeSuffixes and stopRefresh.
+	activeSuffixesMu sync.RWMutex
+)
+
+// SetSuffixSource loads the public suffix list used by every parse from the source.
+// If the source fails to load within the Timeout of opts, the error is returned and the previous list is kept.
+// Setting a new source stops reloading the previous one. Use BuiltinSuffixes to go back to the default.
+// Example: SetSuffixSource(SuffixFile("/etc/psl/public_suffix_list.dat"), SuffixSourceOptions{Refresh: 24 * time.Hour})
+func SetSuffixSource(src SuffixSource, opts SuffixSourceOptions) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout())
+	defer cancel()
+
+	data, err := src.LoadSuffixes(ctx)
+	if err != nil {
+		return err
+	}
+
+	activeSuffixesMu.Lock()
+	defer activeSuffixesMu.Unlock()
+
+	if stopRefresh != nil {
+		close(stopRefresh)
+		stopRefresh = nil
+	}
+
+	activeSuffixes = data
+
+	if opts.Refresh > 0 {
+		stopRefresh = make(chan struct{})
+		go refreshSuffixes(src, opts, stopRefresh)
+	}
+
+	return nil
+}
+
+// CurrentSuffixInfo identifies the public suffix list used by every parse without WithSuffixList.
+func CurrentSuffixInfo() SuffixInfo {
+	return currentSuffixData().Info()
+}
+
+// currentSuffixData returns the list used by every parse without WithSuffixList.
+func currentSuffixData() *SuffixData {
+	activeSuffixesMu.RLock()
+	defer activeSuffixesMu.RUnlock()
+
+	return activeSuffixes
+}
+
+// refreshSuffixes reloads the source in the configured interval until stop is closed.
+func refreshSuffixes(src SuffixSource, opts SuffixSourceOptions, stop chan struct{}) {
+	ticker := time.NewTicker(opts.Refresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), opts.timeout())
+		data, err := src.LoadSuffixes(ctx)
+		cancel()
+
+		var info *SuffixInfo
+		if err == nil {
+			activeSuffixesMu.Lock()
+
+			// The source may have been replaced while it was loading
+			select {
+			case <-stop:
+				activeSuffixesMu.Unlock()
+				return
+			default:
+			}
+
+			activeSuffixes = data
+			activeSuffixesMu.Unlock()
+
+			updated := data.Info()
+			info = &updated
+		}
+
+		if opts.OnUpdate != nil {
+			opts.OnUpdate(info, err)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package domainer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSuffixList = `// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+
+// VERSION: 2024-06-13_08-48-10_UTC
+// COMMIT: 6f9a2b3c
+
+// ===BEGIN ICANN DOMAINS===
+
+com
+uk
+co.uk
+*.ck
+!www.ck
+рф
+
+// ===END ICANN DOMAINS===
+// ===BEGIN PRIVATE DOMAINS===
+
+github.io
+
+// ===END PRIVATE DOMAINS===
+`
+
+var suffixDataTests = []struct {
+	domain   string
+	expected string
+	icann    bool
+}{
+	{"www.example.com", "com", true},
+	{"www.example.co.uk", "co.uk", true},
+	{"WWW.EXAMPLE.CO.UK", "CO.UK", true},
+	{"www.example.ck", "example.ck", true},
+	{"www.ck", "ck", true},
+	{"a.www.ck", "ck", true},
+	{"user.github.io", "github.io", false},
+	{"xn--e1afmkfd.xn--p1ai", "xn--p1ai", true},
+	{"пример.рф", "рф", true},
+	{"www.example.test", "test", false},
+}
+
+func TestSuffixData(t *testing.T) {
+	data, err := ParseSuffixData(strings.NewReader(testSuffixList))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for _, tt := range suffixDataTests {
+		t.Run(tt.domain, func(t *testing.T) {
+			suffix, icann := data.lookup(tt.domain)
+			if suffix != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, suffix)
+			}
+			if icann != tt.icann {
+				t.Errorf("ICANN: Expected %t, got %t", tt.icann, icann)
+			}
+		})
+	}
+
+	info := data.Info()
+	if info.Version != "2024-06-13_08-48-10_UTC" {
+		t.Errorf("Version: Expected '2024-06-13_08-48-10_UTC', got '%s'", info.Version)
+	}
+	if expected := time.Date(2024, 6, 13, 8, 48, 10, 0, time.UTC); !info.Date.Equal(expected) {
+		t.Errorf("Date: Expected '%s', got '%s'", expected, info.Date)
+	}
+	if len(info.Checksum) != 64 {
+		t.Errorf("Checksum: Expected a SHA-256, got '%s'", info.Checksum)
+	}
+}
+
+func TestParseSuffixDataEmpty(t *testing.T) {
+	if _, err := ParseSuffixData(strings.NewReader("// nothing but comments\n")); err == nil {
+		t.Errorf("Expected an error")
+	}
+}
+
+func TestWithSuffixData(t *testing.T) {
+	stubLookup(t)
+
+	data, err := ParseSuffixData(strings.NewReader(testSuffixList))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	u, err := FromString("https://user.github.io", WithSuffixList(data))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if u.Domain != "user" || u.TLD != "github.io" {
+		t.Errorf("Expected 'user' and 'github.io', got '%s' and '%s'", u.Domain, u.TLD)
+	}
+	if u.PublicSuffixList == nil || u.PublicSuffixList.Version != "2024-06-13_08-48-10_UTC" {
+		t.Errorf("Expected the version of the list, got %v", u.PublicSuffixList)
+	}
+}
+
+func TestBuiltinSuffixes(t *testing.T) {
+	stubLookup(t)
+
+	info := CurrentSuffixInfo()
+	if info.Source != "golang.org/x/net/publicsuffix" || info.Version == "" || info.Date.IsZero() {
+		t.Errorf("Expected the version of golang.org/x/net/publicsuffix, got %+v", info)
+	}
+
+	u, err := FromString("https://www.example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if u.PublicSuffixList == nil || *u.PublicSuffixList != info {
+		t.Errorf("Expected %+v, got %+v", info, u.PublicSuffixList)
+	}
+
+	u, err = FromString("http://127.0.0.1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if u.PublicSuffixList != nil {
+		t.Errorf("Expected no list for an IP address, got %+v", u.PublicSuffixList)
+	}
+}
+
+// resetSuffixSource goes back to the built-in list after the test.
+func resetSuffixSource(t *testing.T) {
+	t.Cleanup(func() {
+		if err := SetSuffixSource(BuiltinSuffixes(), SuffixSourceOptions{}); err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	})
+}
+
+func TestSetSuffixSource(t *testing.T) {
+	stubLookup(t)
+	resetSuffixSource(t)
+
+	path := filepath.Join(t.TempDir(), "public_suffix_list.dat")
+	if err := os.WriteFile(path, []byte(testSuffixList), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetSuffixSource(SuffixFile(path), SuffixSourceOptions{}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	u, err := FromString("https://www.example.ck")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if u.Domain != "www" || u.TLD != "example.ck" {
+		t.Errorf("Expected 'www' and 'example.ck', got '%s' and '%s'", u.Domain, u.TLD)
+	}
+	if u.PublicSuffixList == nil || u.PublicSuffixList.Source != path {
+		t.Errorf("Expected the list from '%s', got %+v", path, u.PublicSuffixList)
+	}
+
+	// A source that fails to load keeps the previous list
+	if err := SetSuffixSource(SuffixFile(path+".missing"), SuffixSourceOptions{}); err == nil {
+		t.Errorf("Expected an error for a missing file")
+	}
+	if info := CurrentSuffixInfo(); info.Source != path {
+		t.Errorf("Expected the list from '%s', got %+v", path, info)
+	}
+
+	if err := SetSuffixSource(SuffixBytes([]byte(testSuffixList)), SuffixSourceOptions{}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if info := CurrentSuffixInfo(); info.Source != "embedded" {
+		t.Errorf("Expected the embedded list, got %+v", info)
+	}
+}
+
+func TestSuffixURLRefresh(t *testing.T) {
+	resetSuffixSource(t)
+
+	versions := make(chan string, 1)
+	versions <- "2024-06-13_08-48-10_UTC"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		version := "2024-06-14_08-48-10_UTC"
+		select {
+		case version = <-versions:
+		default:
+		}
+
+		_, _ = w.Write([]byte(strings.Replace(testSuffixList, "2024-06-13_08-48-10_UTC", version, 1)))
+	}))
+	defer server.Close()
+
+	updates := make(chan *SuffixInfo, 1)
+	opts := SuffixSourceOptions{
+		Refresh: 10 * time.Millisecond,
+		OnUpdate: func(info *SuffixInfo, err error) {
+			select {
+			case updates <- info:
+			default:
+			}
+		},
+	}
+
+	if err := SetSuffixSource(SuffixURL(server.URL, server.Client()), opts); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if info := CurrentSuffixInfo(); info.Version != "2024-06-13_08-48-10_UTC" || info.Source != server.URL {
+		t.Errorf("Expected the first version from '%s', got %+v", server.URL, info)
+	}
+
+	select {
+	case info := <-updates:
+		if info == nil || info.Version != "2024-06-14_08-48-10_UTC" {
+			t.Errorf("Expected the refreshed version, got %+v", info)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the list to be refreshed")
+	}
+
+	if info := CurrentSuffixInfo(); info.Version != "2024-06-14_08-48-10_UTC" {
+		t.Errorf("Expected the refreshed version, got %+v", info)
+	}
+}
+
+func TestSuffixURLTimeout(t *testing.T) {
+	resetSuffixSource(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	err := SetSuffixSource(SuffixURL(server.URL, server.Client()), SuffixSourceOptions{Timeout: 50 * time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected '%v', got '%v'", context.DeadlineExceeded, err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the load to time out, took %s", elapsed)
+	}
+	if info := CurrentSuffixInfo(); info.Source != "golang.org/x/net/publicsuffix" {
+		t.Errorf("Expected the previous list to be kept, got %+v", info)
+	}
+}
+
+func TestSuffixURLStatus(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	if _, err := SuffixURL(server.URL, nil).LoadSuffixes(context.Background()); err == nil {
+		t.Errorf("Expected an error for status 404")
+	}
+}
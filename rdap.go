@@ -0,0 +1,145 @@
+package domainer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/idna"
+)
+
+// defaultRDAPServer redirects RDAP queries to the authoritative server of the TLD.
+const defaultRDAPServer = "https://rdap.org"
+
+// Registration is the registration data of a registrable domain.
+type Registration struct {
+	// Registered is the date the domain has been registered.
+	// Example: 1995-08-14 04:00:00 +0000 UTC for "example.com"
+	Registered time.Time `json:"registered"`
+
+	// Expires is the date the registration expires.
+	// Example: 2024-08-13 04:00:00 +0000 UTC for "example.com"
+	Expires time.Time `json:"expires"`
+
+	// Updated is the date the registration has been changed the last time.
+	Updated time.Time `json:"updated"`
+
+	// Registrar is the name of the registrar the domain is registered with.
+	// Example: "RESERVED-Internet Assigned Numbers Authority" for "example.com"
+	Registrar string `json:"registrar"`
+
+	// Nameservers are the nameservers of the domain, in lower case.
+	// Example: []string{"a.iana-servers.net", "b.iana-servers.net"} for "example.com"
+	Nameservers []string `json:"nameservers"`
+}
+
+// rdapDomain is the part of an RDAP domain response we are interested in.
+type rdapDomain struct {
+	Events []struct {
+		Action string    `json:"eventAction"`
+		Date   time.Time `json:"eventDate"`
+	} `json:"events"`
+
+	Entities []struct {
+		Roles      []string          `json:"roles"`
+		VCardArray []json.RawMessage `json:"vcardArray"`
+	} `json:"entities"`
+
+	Nameservers []struct {
+		LDHName string `json:"ldhName"`
+	} `json:"nameservers"`
+}
+
+// lookupRDAP queries the RDAP server for the registration data of the given domain.
+// Internationalized domains are queried in their ASCII form, like the ldhName of RDAP.
+func lookupRDAP(ctx context.Context, client *http.Client, server, domain string) (*Registration, error) {
+	if !strings.Contains(domain, ".") {
+		return nil, fmt.Errorf("%w: %q", ErrPublicSuffix, domain)
+	}
+
+	ascii, err := idna.ToASCII(domain)
+	if err != nil {
+		return nil, fmt.Errorf("domainer: invalid domain %q: %w", domain, err)
+	}
+	domain = ascii
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(server, "/")+"/domain/"+domain, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/rdap+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("domainer: rdap lookup of %q failed with status %s", domain, resp.Status)
+	}
+
+	var data rdapDomain
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("domainer: invalid rdap response for %q: %w", domain, err)
+	}
+
+	registration := &Registration{}
+
+	for _, event := range data.Events {
+		switch event.Action {
+		case "registration":
+			registration.Registered = event.Date
+		case "expiration":
+			registration.Expires = event.Date
+		case "last changed":
+			registration.Updated = event.Date
+		}
+	}
+
+	for _, entity := range data.Entities {
+		for _, role := range entity.Roles {
+			if role == "registrar" {
+				registration.Registrar = vcardName(entity.VCardArray)
+			}
+		}
+	}
+
+	for _, nameserver := range data.Nameservers {
+		registration.Nameservers = append(registration.Nameservers, strings.ToLower(nameserver.LDHName))
+	}
+
+	return registration, nil
+}
+
+// vcardName returns the formatted name ("fn") of a jCard.
+// Example: ["vcard", [["version", {}, "text", "4.0"], ["fn", {}, "text", "Example Registrar"]]]
+func vcardName(vcard []json.RawMessage) string {
+	if len(vcard) < 2 {
+		return ""
+	}
+
+	var properties [][]json.RawMessage
+	if err := json.Unmarshal(vcard[1], &properties); err != nil {
+		return ""
+	}
+
+	for _, property := range properties {
+		if len(property) < 4 {
+			continue
+		}
+
+		var name, value string
+		if json.Unmarshal(property[0], &name) != nil || name != "fn" {
+			continue
+		}
+		if json.Unmarshal(property[3], &value) == nil {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,125 @@
+package domainer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const rdapResponse = `{
+	"objectClassName": "domain",
+	"ldhName": "EXAMPLE.COM",
+	"events": [
+		{"eventAction": "registration", "eventDate": "1995-08-14T04:00:00Z"},
+		{"eventAction": "expiration", "eventDate": "2024-08-13T04:00:00Z"},
+		{"eventAction": "last changed", "eventDate": "2023-08-14T07:01:38Z"}
+	],
+	"entities": [
+		{
+			"roles": ["registrar"],
+			"vcardArray": ["vcard", [["version", {}, "text", "4.0"], ["fn", {}, "text", "Example Registrar"]]]
+		}
+	],
+	"nameservers": [
+		{"ldhName": "A.IANA-SERVERS.NET"},
+		{"ldhName": "B.IANA-SERVERS.NET"}
+	]
+}`
+
+func TestEnrichRDAP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/domain/example.com" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(rdapResponse))
+	}))
+	defer server.Close()
+
+	u := &URL{Subdomain: "www", Hostname: "example.com", Domain: "example", TLD: "com"}
+	if err := u.Enrich(context.Background(), WithRDAPServer(server.URL)); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &Registration{
+		Registered:  time.Date(1995, 8, 14, 4, 0, 0, 0, time.UTC),
+		Expires:     time.Date(2024, 8, 13, 4, 0, 0, 0, time.UTC),
+		Updated:     time.Date(2023, 8, 14, 7, 1, 38, 0, time.UTC),
+		Registrar:   "Example Registrar",
+		Nameservers: []string{"a.iana-servers.net", "b.iana-servers.net"},
+	}
+	if !reflect.DeepEqual(u.Registration, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, u.Registration)
+	}
+}
+
+func TestEnrichRDAPErrors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	u := &URL{Hostname: "example.com", Domain: "example", TLD: "com"}
+	if err := u.Enrich(context.Background(), WithRDAPServer(server.URL)); err == nil {
+		t.Errorf("Expected error for unknown domain")
+	}
+
+	u = &URL{Hostname: "localhost", Domain: "localhost"}
+	if err := u.Enrich(context.Background(), WithRDAPServer(server.URL)); err == nil {
+		t.Errorf("Expected error for single-label host")
+	}
+	if u.Registration != nil {
+		t.Errorf("Expected no registration, got %+v", u.Registration)
+	}
+}
+
+func TestEnrichRDAPHost(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(rdapResponse))
+	}))
+	defer server.Close()
+
+	u := &URL{Hostname: "bücher.example", Domain: "bücher", TLD: "example"}
+	if err := u.Enrich(context.Background(), WithRDAPServer(server.URL)); err != nil {
+		t.Fatal(err)
+	}
+
+	reference, err := ParseReference("?page=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalidTests := []struct {
+		url      *URL
+		expected error
+	}{
+		{&URL{Protocol: "http", Hostname: "127.0.0.1", Domain: "127.0.0.1"}, ErrPublicSuffix},
+		{reference, ErrNoHost},
+		{&URL{}, ErrNoHost},
+	}
+
+	for _, tt := range invalidTests {
+		if err := tt.url.Enrich(context.Background(), WithRDAPServer(server.URL)); !errors.Is(err, tt.expected) {
+			t.Errorf("%+v: Expected '%v', got '%v'", *tt.url, tt.expected, err)
+		}
+	}
+
+	expected := []string{"/domain/xn--bcher-kva.example"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("Expected requests to %q, got %q", expected, paths)
+	}
+}
+
+func TestEnrichWithoutOptions(t *testing.T) {
+	u := &URL{Hostname: "example.com", Domain: "example", TLD: "com"}
+	if err := u.Enrich(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if u.Registration != nil {
+		t.Errorf("Expected no registration, got %+v", u.Registration)
+	}
+}
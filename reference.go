@@ -0,0 +1,73 @@
+package domainer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseReference parses a URL reference as it is found in HTML, which may be relative.
+// Absolute URLs are parsed with FromString. For relative references, only the present parts are set
+// and Relative is true:
+//   - "//cdn.example.com/lib.js" sets the host, port and path, but no protocol. The host is not looked up,
+//     unless RejectPrivate or ReverseLookup need its addresses
+//   - "../img/logo.png", "?page=2" and "#section" only set path, query and fragment, and are not looked up
+//
+// References with a scheme but without a host, like "mailto:" or "javascript:", fail with ErrNoHost.
+//
+// Use Resolve to turn a reference into an absolute URL.
+func ParseReference(ref string, opts ...Option) (*URL, error) {
+	if hasScheme(ref) {
+		return FromString(ref, opts...)
+	}
+
+	// References like "mailto:" or "javascript:" are absolute, they must not be taken for a relative path
+	if hasOpaqueScheme(ref) {
+		return nil, fmt.Errorf("%w: reference %q has a scheme, but no host", ErrNoHost, ref)
+	}
+
+	o := newOptions(opts)
+
+	// Scheme-relative references have a host, but no protocol to derive a port from
+	// The host is only split, not looked up
+	if strings.HasPrefix(ref, "//") {
+		o.skipLookup = true
+
+		u := &URL{}
+		if err := u.parse(strings.TrimPrefix(ref, "//"), o); err != nil {
+			return nil, err
+		}
+
+		u.FullURL = ref
+		u.EffectivePort = u.Port
+		u.Relative = true
+
+		return u, nil
+	}
+
+	u := &URL{
+		FullURL:  ref,
+		Relative: true,
+	}
+
+	// Split the reference into path, query and fragment
+	u.parsePath(ref)
+
+	// Split the fragment into path and query if requested
+	if o.parseFragment {
+		u.parseFragment()
+	}
+
+	// Store the percent-decoded values if requested
+	if o.decodeValues {
+		if err := u.decode(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Mark the query values used for tracking if requested
+	if o.markTracking {
+		u.markTracking()
+	}
+
+	return u, nil
+}
@@ -0,0 +1,139 @@
+package domainer
+
+import (
+	"errors"
+	"testing"
+)
+
+var referenceTests = []struct {
+	name     string
+	ref      string
+	expected URL
+}{
+	{
+		"Absolute URL", "https://www.example.com/a?b=c", URL{
+			Protocol:      "https",
+			Subdomain:     "www",
+			Domain:        "example",
+			TLD:           "com",
+			EffectivePort: 443,
+			Path:          "/a",
+			Query:         []Query{{Key: "b", Value: "c"}},
+		},
+	},
+	{
+		"Scheme-relative", "//cdn.example.com/lib.js", URL{
+			Subdomain: "cdn",
+			Domain:    "example",
+			TLD:       "com",
+			Path:      "/lib.js",
+			Relative:  true,
+		},
+	},
+	{
+		"Scheme-relative with port", "//cdn.example.com:8080/lib.js", URL{
+			Subdomain:     "cdn",
+			Domain:        "example",
+			TLD:           "com",
+			Port:          8080,
+			EffectivePort: 8080,
+			Path:          "/lib.js",
+			Relative:      true,
+		},
+	},
+	{
+		"Query only", "?page=2", URL{
+			Query:    []Query{{Key: "page", Value: "2"}},
+			Relative: true,
+		},
+	},
+	{
+		"Fragment only", "#section", URL{
+			Fragment: "section",
+			Relative: true,
+		},
+	},
+	{
+		"Relative path", "../img/logo.png?v=1#top", URL{
+			Path:     "../img/logo.png",
+			Query:    []Query{{Key: "v", Value: "1"}},
+			Fragment: "top",
+			Relative: true,
+		},
+	},
+	{
+		"Host-like relative path", "example.com/about", URL{
+			Path:     "example.com/about",
+			Relative: true,
+		},
+	},
+	{
+		"Empty reference", "", URL{
+			Relative: true,
+		},
+	},
+}
+
+func TestParseReference(t *testing.T) {
+	stubLookup(t)
+
+	for _, tt := range referenceTests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := ParseReference(tt.ref)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !Equal(u, &tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, *u)
+			}
+			if u.Relative != tt.expected.Relative {
+				t.Errorf("Relative: Expected %t, got %t", tt.expected.Relative, u.Relative)
+			}
+			if u.EffectivePort != tt.expected.EffectivePort {
+				t.Errorf("EffectivePort: Expected %d, got %d", tt.expected.EffectivePort, u.EffectivePort)
+			}
+			if u.FullURL != tt.ref {
+				t.Errorf("FullURL: Expected '%s', got '%s'", tt.ref, u.FullURL)
+			}
+			if u.String() != tt.ref {
+				t.Errorf("String: Expected '%s', got '%s'", tt.ref, u.String())
+			}
+		})
+	}
+}
+
+func TestParseReferenceDecodeValues(t *testing.T) {
+	u, err := ParseReference("my%20docs/#page%202", DecodeValues())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.Path != "my docs/" || u.Fragment != "page 2" {
+		t.Errorf("Expected 'my docs/' and 'page 2', got '%s' and '%s'", u.Path, u.Fragment)
+	}
+}
+
+func TestParseReferenceOpaqueScheme(t *testing.T) {
+	for _, ref := range []string{"javascript:alert(1)", "mailto:x@y.com", "tel:+123"} {
+		if u, err := ParseReference(ref); !errors.Is(err, ErrNoHost) {
+			t.Errorf("%s: Expected '%v', got '%v' and %+v", ref, ErrNoHost, err, u)
+		}
+	}
+}
+
+func TestParseReferenceSkipsLookup(t *testing.T) {
+	stubResolver(t, &staticResolver{err: errors.New("no such host")})
+
+	u, err := ParseReference("//cdn.example.com/lib.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Hostname != "example.com" || u.IPAddress != "" {
+		t.Errorf("Expected host 'example.com' without IP address, got '%s' and '%s'", u.Hostname, u.IPAddress)
+	}
+
+	if _, err := ParseReference("//cdn.example.com/lib.js", RejectPrivate()); !errors.Is(err, ErrDNSLookup) {
+		t.Errorf("Expected '%v' with RejectPrivate, got '%v'", ErrDNSLookup, err)
+	}
+}
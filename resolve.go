@@ -0,0 +1,144 @@
+package domainer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Resolve resolves a URL reference relative to u, following RFC 3986 section 5.2, and parses the result.
+// Absolute references are parsed as they are. The given Option values are passed on to FromString.
+// References with a scheme but without a host, like "mailto:", "javascript:" or "tel:", are absolute as well,
+// but can't be represented by a URL, so ErrNoHost is returned for them.
+// Example: "../img/logo.png" relative to "https://www.example.com/blog/post/" is
+// "https://www.example.com/blog/img/logo.png"
+func (u *URL) Resolve(ref string, opts ...Option) (*URL, error) {
+	if hasScheme(ref) {
+		return FromString(ref, opts...)
+	}
+
+	if hasOpaqueScheme(ref) {
+		return nil, fmt.Errorf("%w: reference %q has a scheme, but no host", ErrNoHost, ref)
+	}
+
+	// Scheme-relative references only inherit the protocol
+	if strings.HasPrefix(ref, "//") {
+		if u.Protocol == "" {
+			return FromString(strings.TrimPrefix(ref, "//"), opts...)
+		}
+		return FromString(u.Protocol+":"+ref, opts...)
+	}
+
+	// Split the reference into path, query and fragment
+	path, fragment, hasFragment := strings.Cut(ref, "#")
+	path, query, hasQuery := strings.Cut(path, "?")
+
+	// The resolved URL keeps everything up to the path from the base
+	// A decoded base is encoded again, the reference is not decoded yet
+	username, password, basePath := u.rawValues()
+	resolved := &URL{
+		Protocol:  u.Protocol,
+		Username:  username,
+		Password:  password,
+		Subdomain: u.Subdomain,
+		Domain:    u.Domain,
+		TLD:       u.TLD,
+		Port:      u.Port,
+	}
+
+	switch {
+	case path == "":
+		// An empty path keeps the base path, and the base query unless a new one is given
+		resolved.Path = basePath
+		if !hasQuery {
+			resolved.Query = u.Query
+		}
+	case strings.HasPrefix(path, "/"):
+		resolved.Path = removeDotSegments(path)
+	default:
+		resolved.Path = removeDotSegments(mergePaths(basePath, path))
+	}
+
+	url := resolved.String()
+	if hasQuery {
+		url += "?" + query
+	}
+	if hasFragment {
+		url += "#" + fragment
+	}
+
+	return FromString(url, opts...)
+}
+
+// hasScheme reports whether the reference starts with a scheme followed by "://".
+func hasScheme(ref string) bool {
+	scheme, _, found := strings.Cut(ref, "://")
+	if !found || scheme == "" {
+		return false
+	}
+
+	for i, c := range scheme {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case i > 0 && ('0' <= c && c <= '9' || c == '+' || c == '-' || c == '.'):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// mergePaths appends a relative path to the directory of the base path.
+func mergePaths(base, ref string) string {
+	if base == "" {
+		return "/" + ref
+	}
+
+	return base[:strings.LastIndex(base, "/")+1] + ref
+}
+
+// removeDotSegments removes "." and ".." segments from an absolute path.
+// Example: "/a/b/../c/./d" becomes "/a/c/d"
+func removeDotSegments(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	segments := strings.Split(path, "/")
+	out := make([]string, 0, len(segments))
+
+	for i, segment := range segments {
+		last := i == len(segments)-1
+
+		switch segment {
+		case ".":
+			// A trailing dot segment still refers to a directory
+			if last {
+				out = append(out, "")
+			}
+		case "..":
+			// Never remove the leading empty segment of the absolute path
+			if len(out) > 1 {
+				out = out[:len(out)-1]
+			}
+			if last {
+				out = append(out, "")
+			}
+		default:
+			out = append(out, segment)
+		}
+	}
+
+	return strings.Join(out, "/")
+}
+
+// hasOpaqueScheme reports whether the reference starts with a scheme that is not followed by "//".
+// Example: "mailto:user@example.com" or "javascript:void(0)"
+func hasOpaqueScheme(ref string) bool {
+	colonIndex := strings.Index(ref, ":")
+	if colonIndex < 1 || hasScheme(ref) {
+		return false
+	}
+
+	return hasScheme(ref[:colonIndex] + "://")
+}
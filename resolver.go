@@ -0,0 +1,218 @@
+package domainer
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Resolver looks up the IP addresses of hosts and the hostnames of IP addresses.
+// *net.Resolver satisfies this interface.
+type Resolver interface {
+	// LookupIPAddr returns the IP addresses of the host.
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+
+	// LookupAddr returns the hostnames of the IP address (PTR records).
+	LookupAddr(ctx context.Context, addr string) ([]string, error)
+}
+
+// defaultResolver is used if no Resolver is passed with WithResolver.
+var defaultResolver Resolver = net.DefaultResolver
+
+// maxCachedAnswers is the number of answers a CachingResolver keeps at most.
+const maxCachedAnswers = 10000
+
+// CachingResolver caches the answers of another Resolver.
+// Failed lookups are not cached. At most 10000 answers are kept. When the cache is full, expired answers are
+// removed, and if that isn't enough, random ones.
+type CachingResolver struct {
+	resolver   Resolver
+	ttl        time.Duration
+	maxAnswers int
+
+	mu      sync.Mutex
+	answers map[string]cachedAnswer
+}
+
+// cachedAnswer is an answer of a Resolver along with the time it expires.
+type cachedAnswer struct {
+	ips     []net.IPAddr
+	names   []string
+	mx      []*net.MX
+	expires time.Time
+}
+
+// NewCachingResolver returns a CachingResolver that keeps the answers of resolver for ttl.
+// If resolver is nil, net.DefaultResolver is used.
+func NewCachingResolver(resolver Resolver, ttl time.Duration) *CachingResolver {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	return &CachingResolver{
+		resolver:   resolver,
+		ttl:        ttl,
+		maxAnswers: maxCachedAnswers,
+		answers:    map[string]cachedAnswer{},
+	}
+}
+
+// LookupIPAddr returns the IP addresses of the host, from the cache if possible.
+func (r *CachingResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	key := "ip:" + strings.ToLower(host)
+	if answer, ok := r.cached(key); ok {
+		return answer.ips, nil
+	}
+
+	ips, err := r.resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	r.store(key, cachedAnswer{ips: ips})
+
+	return ips, nil
+}
+
+// LookupAddr returns the hostnames of the IP address, from the cache if possible.
+func (r *CachingResolver) LookupAddr(ctx context.Context, addr string) ([]string, error) {
+	key := "ptr:" + addr
+	if answer, ok := r.cached(key); ok {
+		return answer.names, nil
+	}
+
+	names, err := r.resolver.LookupAddr(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	r.store(key, cachedAnswer{names: names})
+
+	return names, nil
+}
+
+// LookupMX returns the MX records of the domain, from the cache if possible.
+// It fails if the underlying Resolver doesn't satisfy MXResolver.
+func (r *CachingResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	mxResolver, ok := r.resolver.(MXResolver)
+	if !ok {
+		return nil, errors.New("resolver can't look up MX records")
+	}
+
+	key := "mx:" + strings.ToLower(name)
+	if answer, ok := r.cached(key); ok {
+		return answer.mx, nil
+	}
+
+	mx, err := mxResolver.LookupMX(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	r.store(key, cachedAnswer{mx: mx})
+
+	return mx, nil
+}
+
+// cached returns the answer stored for the key, unless it has expired.
+// Expired answers are removed.
+func (r *CachingResolver) cached(key string) (cachedAnswer, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	answer, ok := r.answers[key]
+	if !ok {
+		return cachedAnswer{}, false
+	}
+
+	if time.Now().After(answer.expires) {
+		delete(r.answers, key)
+		return cachedAnswer{}, false
+	}
+
+	return answer, true
+}
+
+// store caches the answer for the key, making room for it if the cache is full.
+func (r *CachingResolver) store(key string, answer cachedAnswer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if _, ok := r.answers[key]; !ok && len(r.answers) >= r.maxAnswers {
+		r.evict(now)
+	}
+
+	answer.expires = now.Add(r.ttl)
+	r.answers[key] = answer
+}
+
+// evict removes all expired answers. If the cache is still full, random answers are removed until there is
+// room for one more. r.mu has to be held.
+func (r *CachingResolver) evict(now time.Time) {
+	for key, answer := range r.answers {
+		if now.After(answer.expires) {
+			delete(r.answers, key)
+		}
+	}
+
+	for key := range r.answers {
+		if len(r.answers) < r.maxAnswers {
+			break
+		}
+		delete(r.answers, key)
+	}
+}
+
+// resolve looks up the IP addresses of the host, which is passed in so it doesn't have to be joined again.
+// IP addresses are used as they are.
+func (u *URL) resolve(resolver Resolver, host string) error {
+	if ip := u.ip(); ip != nil {
+		u.IPAddresses = append(u.IPAddresses[:0], ip.String())
+		u.IPAddress = u.IPAddresses[0]
+		return nil
+	}
+
+	ips, err := resolver.LookupIPAddr(context.Background(), host)
+	if err == nil && len(ips) == 0 {
+		err = errors.New("no addresses found")
+	}
+	if err != nil {
+		return &DNSLookupError{Host: host, Err: err}
+	}
+
+	for _, ip := range ips {
+		u.IPAddresses = append(u.IPAddresses, ip.String())
+	}
+	u.IPAddress = u.IPAddresses[0]
+
+	return nil
+}
+
+// reverseResolve looks up the hostnames of all IP addresses of the URL.
+// Addresses without PTR records are skipped.
+func (u *URL) reverseResolve(resolver Resolver) error {
+	seen := map[string]bool{}
+
+	for _, address := range u.IPAddresses {
+		names, err := resolver.LookupAddr(context.Background(), address)
+
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			continue
+		}
+		if err != nil {
+			return &DNSLookupError{Host: address, Err: err}
+		}
+
+		for _, name := range names {
+			name = strings.TrimSuffix(name, ".")
+			if !seen[name] {
+				seen[name] = true
+				u.ReverseHostnames = append(u.ReverseHostnames, name)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package domainer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReverseLookup(t *testing.T) {
+	resolver := &staticResolver{
+		ips:   []net.IP{net.IPv4(93, 184, 216, 34)},
+		names: []string{"host.example.net.", "alias.example.net.", "host.example.net."},
+	}
+
+	u, err := FromString("http://93.184.216.34:8080/", WithResolver(resolver), ReverseLookup())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"host.example.net", "alias.example.net"}
+	if !reflect.DeepEqual(u.ReverseHostnames, expected) {
+		t.Errorf("ReverseHostnames: Expected %q, got %q", expected, u.ReverseHostnames)
+	}
+
+	u, err = FromString("https://www.example.com", WithResolver(resolver))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.ReverseHostnames != nil {
+		t.Errorf("Expected no reverse lookup without ReverseLookup, got %q", u.ReverseHostnames)
+	}
+}
+
+func TestReverseLookupErrors(t *testing.T) {
+	notFound := &net.DNSError{Err: "no such host", Name: "34.216.184.93.in-addr.arpa.", IsNotFound: true}
+
+	u, err := FromString("http://93.184.216.34/", WithResolver(&staticResolver{err: notFound}), ReverseLookup())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.ReverseHostnames != nil {
+		t.Errorf("Expected no hostnames, got %q", u.ReverseHostnames)
+	}
+
+	failure := errors.New("server misbehaving")
+	if _, err := FromString("http://93.184.216.34/", WithResolver(&staticResolver{err: failure}), ReverseLookup()); !errors.Is(err, ErrDNSLookup) {
+		t.Errorf("Expected '%v', got '%v'", ErrDNSLookup, err)
+	}
+}
+
+func TestCachingResolver(t *testing.T) {
+	upstream := &staticResolver{ips: []net.IP{net.IPv4(93, 184, 216, 34)}, names: []string{"host.example.net."}}
+	resolver := NewCachingResolver(upstream, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		u, err := FromString("https://www.example.com", WithResolver(resolver))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.IPAddress != "93.184.216.34" {
+			t.Errorf("IPAddress: Expected '%s', got '%s'", "93.184.216.34", u.IPAddress)
+		}
+
+		if _, err := resolver.LookupAddr(context.Background(), "93.184.216.34"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if upstream.lookups != 2 {
+		t.Errorf("Expected %d upstream lookups, got %d", 2, upstream.lookups)
+	}
+}
+
+func TestCachingResolverExpiry(t *testing.T) {
+	upstream := &staticResolver{ips: []net.IP{net.IPv4(93, 184, 216, 34)}}
+	resolver := NewCachingResolver(upstream, 0)
+
+	for i := 0; i < 2; i++ {
+		if _, err := resolver.LookupIPAddr(context.Background(), "example.com"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if upstream.lookups != 2 {
+		t.Errorf("Expected %d upstream lookups, got %d", 2, upstream.lookups)
+	}
+}
+
+func TestCachingResolverSize(t *testing.T) {
+	upstream := &staticResolver{ips: []net.IP{net.IPv4(93, 184, 216, 34)}}
+
+	resolver := NewCachingResolver(upstream, time.Minute)
+	resolver.maxAnswers = 3
+	for i := 0; i < 10; i++ {
+		if _, err := resolver.LookupIPAddr(context.Background(), fmt.Sprintf("host%d.example.com", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(resolver.answers) != 3 {
+		t.Errorf("Expected %d cached answers, got %d", 3, len(resolver.answers))
+	}
+
+	// Expired answers are removed before answers that are still valid
+	resolver = NewCachingResolver(upstream, time.Minute)
+	resolver.maxAnswers = 2
+	resolver.answers["ip:expired.example.com"] = cachedAnswer{expires: time.Now().Add(-time.Second)}
+	for _, host := range []string{"a.example.com", "b.example.com"} {
+		if _, err := resolver.LookupIPAddr(context.Background(), host); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := resolver.answers["ip:expired.example.com"]; ok || len(resolver.answers) != 2 {
+		t.Errorf("Expected the expired answer to be removed, got %v", resolver.answers)
+	}
+}
+
+func TestCachingResolverErrors(t *testing.T) {
+	upstream := &staticResolver{err: errors.New("server misbehaving")}
+	resolver := NewCachingResolver(upstream, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if _, err := resolver.LookupIPAddr(context.Background(), "example.com"); err == nil {
+			t.Errorf("Expected error")
+		}
+	}
+
+	if upstream.lookups != 2 {
+		t.Errorf("Expected errors not to be cached, got %d upstream lookups", upstream.lookups)
+	}
+}
@@ -0,0 +1,55 @@
+package domainer
+
+import "strings"
+
+// SubdomainLabels returns the labels of the subdomain.
+// Example: []string{"a", "b"} in "https://a.b.example.com"
+func (u *URL) SubdomainLabels() []string {
+	if u.Subdomain == "" {
+		return nil
+	}
+
+	return strings.Split(u.Subdomain, ".")
+}
+
+// SubdomainDepth returns the number of labels in the subdomain.
+// Example: 2 in "https://a.b.example.com"
+func (u *URL) SubdomainDepth() int {
+	return len(u.SubdomainLabels())
+}
+
+// ParentDomains returns the host followed by each of its parent domains, down to the registrable domain.
+// Example: []string{"a.b.example.com", "b.example.com", "example.com"} in "https://a.b.example.com"
+func (u *URL) ParentDomains() []string {
+	labels := u.SubdomainLabels()
+	registrable := u.RegistrableDomain()
+
+	parents := make([]string, 0, len(labels)+1)
+	for i := range labels {
+		parents = append(parents, strings.Join(labels[i:], ".")+"."+registrable)
+	}
+
+	return append(parents, registrable)
+}
+
+// IsSubdomainOf reports whether the host is below the given domain. The domain itself is not a subdomain of itself.
+// Example: "https://www.example.com" is a subdomain of "example.com", but not of "www.example.com"
+func (u *URL) IsSubdomainOf(domain string) bool {
+	domain = strings.ToLower(strings.Trim(domain, "."))
+	if domain == "" {
+		return false
+	}
+
+	return strings.HasSuffix(strings.ToLower(u.host()), "."+domain)
+}
+
+// RegistrableDomain returns the registrable domain (eTLD+1), which is the public suffix plus one more label.
+// IP addresses and single-label hosts have no public suffix, so the host itself is returned.
+// Example: "example.co.uk" in "https://www.example.co.uk"
+func (u *URL) RegistrableDomain() string {
+	if u.TLD == "" {
+		return u.Domain
+	}
+
+	return u.Domain + "." + u.TLD
+}
@@ -0,0 +1,70 @@
+package domainer
+
+import (
+	"reflect"
+	"testing"
+)
+
+var subdomainTests = []struct {
+	name    string
+	url     *URL
+	labels  []string
+	parents []string
+}{
+	{
+		"Multiple labels", &URL{Subdomain: "a.b", Domain: "example", TLD: "com"},
+		[]string{"a", "b"}, []string{"a.b.example.com", "b.example.com", "example.com"},
+	},
+	{
+		"Multipart TLD", &URL{Subdomain: "www", Domain: "example", TLD: "co.uk"},
+		[]string{"www"}, []string{"www.example.co.uk", "example.co.uk"},
+	},
+	{
+		"No subdomain", &URL{Domain: "example", TLD: "com"},
+		nil, []string{"example.com"},
+	},
+	{
+		"Single-label host", &URL{Domain: "localhost"},
+		nil, []string{"localhost"},
+	},
+}
+
+func TestSubdomainLabels(t *testing.T) {
+	for _, tt := range subdomainTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.url.SubdomainLabels(), tt.labels) {
+				t.Errorf("SubdomainLabels: Expected %q, got %q", tt.labels, tt.url.SubdomainLabels())
+			}
+			if tt.url.SubdomainDepth() != len(tt.labels) {
+				t.Errorf("SubdomainDepth: Expected %d, got %d", len(tt.labels), tt.url.SubdomainDepth())
+			}
+			if !reflect.DeepEqual(tt.url.ParentDomains(), tt.parents) {
+				t.Errorf("ParentDomains: Expected %q, got %q", tt.parents, tt.url.ParentDomains())
+			}
+		})
+	}
+}
+
+func TestIsSubdomainOf(t *testing.T) {
+	u := &URL{Subdomain: "a.b", Domain: "example", TLD: "com"}
+
+	isSubdomainTests := []struct {
+		domain   string
+		expected bool
+	}{
+		{"example.com", true},
+		{"b.example.com", true},
+		{"B.Example.COM", true},
+		{".example.com", true},
+		{"a.b.example.com", false},
+		{"ample.com", false},
+		{"com", true},
+		{"", false},
+	}
+
+	for _, tt := range isSubdomainTests {
+		if got := u.IsSubdomainOf(tt.domain); got != tt.expected {
+			t.Errorf("IsSubdomainOf(%q): Expected %t, got %t", tt.domain, tt.expected, got)
+		}
+	}
+}
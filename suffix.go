@@ -0,0 +1,127 @@
+package domainer
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// SuffixList is a list of public suffixes.
+// publicsuffix.List satisfies this interface.
+type SuffixList interface {
+	// PublicSuffix returns the public suffix of the given domain.
+	// Example: "co.uk" for "www.example.co.uk"
+	PublicSuffix(domain string) string
+}
+
+// Suffixes is a SuffixList made up of a simple list of suffixes.
+// Hosts that match none of the suffixes fall back to their last label.
+type Suffixes []string
+
+// PublicSuffix returns the longest suffix in the list that matches the given domain.
+func (s Suffixes) PublicSuffix(domain string) string {
+	if suffix := longestSuffix(domain, s); suffix != "" {
+		return suffix
+	}
+
+	return domain[strings.LastIndex(domain, ".")+1:]
+}
+
+var (
+	// registeredSuffixes are public suffixes added with RegisterSuffix.
+	registeredSuffixes []string
+
+	// registeredSuffixesMu guards registeredSuffixes.
+	registeredSuffixesMu sync.RWMutex
+)
+
+// RegisterSuffix adds public suffixes that are used by every parse, in addition to the public suffix list.
+// This is useful for internal or special-use names like "corp.internal" or "test".
+func RegisterSuffix(suffixes ...string) {
+	registeredSuffixesMu.Lock()
+	defer registeredSuffixesMu.Unlock()
+
+	for _, suffix := range suffixes {
+		registeredSuffixes = append(registeredSuffixes, normalizeSuffix(suffix))
+	}
+}
+
+// normalizeSuffix lowercases a suffix and removes leading and trailing dots, so it can be matched
+// against a lowercased host.
+func normalizeSuffix(suffix string) string {
+	return strings.Trim(strings.ToLower(suffix), ".")
+}
+
+// publicSuffix returns the public suffix of the given host.
+// The longest match out of the suffix list, the registered suffixes and the suffixes of the options wins.
+// The host is matched in lower case, but the suffix keeps the case of the host.
+func publicSuffix(host string, o *options) string {
+	lower := strings.ToLower(host)
+
+	var suffix string
+	if o.suffixList != nil {
+		suffix = o.suffixList.PublicSuffix(lower)
+	} else {
+		suffix = listSuffix(lower, o.suffixData, o.icannSuffixesOnly)
+	}
+
+	registeredSuffixesMu.RLock()
+	registered := longestSuffix(lower, registeredSuffixes)
+	registeredSuffixesMu.RUnlock()
+
+	for _, candidate := range []string{registered, longestSuffix(lower, o.suffixes)} {
+		if candidate != "" && strings.Count(candidate, ".") >= strings.Count(suffix, ".") {
+			suffix = candidate
+		}
+	}
+
+	// Lowering some non-ASCII characters changes their length, then the suffix can't be mapped back
+	if len(lower) != len(host) || len(suffix) > len(host) {
+		return suffix
+	}
+
+	return host[len(host)-len(suffix):]
+}
+
+// listSuffix returns the public suffix of the host using the given list.
+// If only ICANN suffixes are used, suffixes from the private section are replaced by the ICANN suffix below them.
+func listSuffix(host string, data *SuffixData, icannOnly bool) string {
+	suffix, icann := data.lookup(host)
+	for icannOnly && !icann && strings.Contains(suffix, ".") {
+		suffix, icann = data.lookup(suffix[strings.Index(suffix, ".")+1:])
+	}
+
+	return suffix
+}
+
+// longestSuffix returns the longest of the given suffixes the host ends with.
+func longestSuffix(host string, suffixes []string) string {
+	var longest string
+	for _, suffix := range suffixes {
+		if (host == suffix || strings.HasSuffix(host, "."+suffix)) && len(suffix) > len(longest) {
+			longest = suffix
+		}
+	}
+
+	return longest
+}
+
+// effectiveTLDPlusOne returns the public suffix of the host plus one more label.
+// Example: "example.co.uk" for "www.example.co.uk"
+func effectiveTLDPlusOne(host string, o *options) (string, error) {
+	if strings.HasPrefix(host, ".") || strings.HasSuffix(host, ".") || strings.Contains(host, "..") {
+		return "", fmt.Errorf("%w: empty label in domain %q", ErrPublicSuffix, host)
+	}
+
+	suffix := publicSuffix(host, o)
+	if len(host) <= len(suffix) {
+		return "", fmt.Errorf("%w: cannot derive eTLD+1 for domain %q", ErrPublicSuffix, host)
+	}
+
+	i := len(host) - len(suffix) - 1
+	if host[i] != '.' {
+		return "", fmt.Errorf("%w: invalid public suffix %q for domain %q", ErrPublicSuffix, suffix, host)
+	}
+
+	return host[1+strings.LastIndex(host[:i], "."):], nil
+}
@@ -0,0 +1,91 @@
+package domainer
+
+import (
+	"testing"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+var suffixTests = []struct {
+	name      string
+	domain    string
+	opts      []Option
+	subdomain string
+	domainer  string
+	tld       string
+}{
+	{"Default list", "https://www.example.co.uk", nil, "www", "example", "co.uk"},
+	{"Private suffix", "https://user.github.io", nil, "", "user", "github.io"},
+	{"Private suffix ignored", "https://user.github.io", []Option{ICANNSuffixesOnly()}, "user", "github", "io"},
+	{"Private suffix below multipart TLD", "https://a.blogspot.co.uk", nil, "", "a", "blogspot.co.uk"},
+	{"Private suffix below multipart TLD ignored", "https://a.blogspot.co.uk", []Option{ICANNSuffixesOnly()}, "a", "blogspot", "co.uk"},
+	{"Unlisted TLD", "https://service.corp.internal", nil, "service", "corp", "internal"},
+	{"Additional suffix", "https://api.service.corp.internal", []Option{WithSuffixes("corp.internal")}, "api", "service", "corp.internal"},
+	{"Additional suffix in upper case", "https://Service.Corp.Internal", []Option{WithSuffixes("Corp.Internal.")}, "", "Service", "Corp.Internal"},
+	{"Upper case host", "https://WWW.Example.CO.UK", nil, "WWW", "Example", "CO.UK"},
+	{"Additional suffix not matching", "https://www.example.com", []Option{WithSuffixes("corp.internal")}, "www", "example", "com"},
+	{"Onion", "http://www.foo.onion", nil, "www", "foo", "onion"},
+	{"Custom list", "https://www.example.co.uk", []Option{WithSuffixList(Suffixes{"uk"})}, "www.example", "co", "uk"},
+	{"Custom list fallback", "https://www.example.test", []Option{WithSuffixList(Suffixes{"uk"})}, "www", "example", "test"},
+	{"publicsuffix.List", "https://user.github.io", []Option{WithSuffixList(publicsuffix.List)}, "", "user", "github.io"},
+}
+
+func TestSuffixes(t *testing.T) {
+	stubLookup(t)
+
+	for _, tt := range suffixTests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := FromString(tt.domain, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if u.Subdomain != tt.subdomain {
+				t.Errorf("Subdomain: Expected '%s', got '%s'", tt.subdomain, u.Subdomain)
+			}
+			if u.Domain != tt.domainer {
+				t.Errorf("Domain: Expected '%s', got '%s'", tt.domainer, u.Domain)
+			}
+			if u.TLD != tt.tld {
+				t.Errorf("TLD: Expected '%s', got '%s'", tt.tld, u.TLD)
+			}
+		})
+	}
+}
+
+func TestRegisterSuffix(t *testing.T) {
+	stubLookup(t)
+
+	RegisterSuffix("Corp.Internal")
+	t.Cleanup(func() {
+		registeredSuffixes = nil
+	})
+
+	u, err := FromString("https://api.service.corp.internal")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.Domain != "service" || u.TLD != "corp.internal" {
+		t.Errorf("Expected 'service' and 'corp.internal', got '%s' and '%s'", u.Domain, u.TLD)
+	}
+
+	u, err = FromString("https://API.Service.CORP.internal")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.Domain != "Service" || u.TLD != "CORP.internal" {
+		t.Errorf("Expected 'Service' and 'CORP.internal', got '%s' and '%s'", u.Domain, u.TLD)
+	}
+}
+
+func TestSuffixErrors(t *testing.T) {
+	stubLookup(t)
+
+	for _, domain := range []string{"https://co.uk", "https://.example.com", "https://example..com"} {
+		if _, err := FromString(domain); err == nil {
+			t.Errorf("Expected error for '%s'", domain)
+		}
+	}
+}
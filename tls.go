@@ -0,0 +1,133 @@
+package domainer
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"strconv"
+	"time"
+)
+
+// TLSOptions configures InspectTLS.
+type TLSOptions struct {
+	// Timeout limits how long connecting to the host may take. No timeout is used if it is 0.
+	Timeout time.Duration
+
+	// ServerName overrides the server name sent to the host. By default, the host of the URL is used.
+	ServerName string
+
+	// RootCAs are the certificate authorities the certificate is verified against.
+	// By default, the system certificate pool is used.
+	RootCAs *x509.CertPool
+}
+
+// TLSInfo describes the certificate presented by the host.
+type TLSInfo struct {
+	// Subject is the subject of the certificate.
+	// Example: "CN=www.example.org,O=Internet Corporation for Assigned Names and Numbers,L=Los Angeles,ST=California,C=US"
+	Subject string `json:"subject"`
+
+	// Issuer is the issuer of the certificate.
+	// Example: "CN=DigiCert TLS RSA SHA256 2020 CA1,O=DigiCert Inc,C=US"
+	Issuer string `json:"issuer"`
+
+	// DNSNames are the DNS names in the subject alternative names of the certificate.
+	// Example: []string{"www.example.org", "example.com"}
+	DNSNames []string `json:"dns_names"`
+
+	// IPAddresses are the IP addresses in the subject alternative names of the certificate.
+	IPAddresses []string `json:"ip_addresses"`
+
+	// NotBefore is the date the certificate becomes valid.
+	NotBefore time.Time `json:"not_before"`
+
+	// NotAfter is the date the certificate expires.
+	NotAfter time.Time `json:"not_after"`
+
+	// MatchesHost is true if the certificate is valid for the host of the URL.
+	MatchesHost bool `json:"matches_host"`
+
+	// Trusted is true if the certificate chains up to a trusted certificate authority and is not expired.
+	Trusted bool `json:"trusted"`
+}
+
+// InspectTLS connects to the host and stores the certificate it presents in u.TLS.
+// It connects to IPAddress if the URL has one, and sends the host as the server name.
+// Port 443 is used unless the URL has an explicit port, also for "http" URLs.
+// Relative references and URLs without a host fail with ErrNoHost.
+// The connection succeeds even if the certificate is invalid, so it can still be inspected.
+func (u *URL) InspectTLS(ctx context.Context, opts TLSOptions) error {
+	// An empty host would connect to the local machine
+	if u.Relative || u.host() == "" {
+		return ErrNoHost
+	}
+
+	host := u.host()
+	if ip := u.ip(); ip != nil {
+		host = u.Domain
+	}
+
+	serverName := opts.ServerName
+	if serverName == "" {
+		serverName = host
+	}
+
+	address := host
+	if u.IPAddress != "" {
+		address = u.IPAddress
+	}
+
+	port := u.Port
+	if port == 0 {
+		port = 443
+	}
+
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: opts.Timeout},
+		Config: &tls.Config{
+			ServerName: serverName,
+			// The certificate is verified below, so we can report on invalid ones as well
+			InsecureSkipVerify: true,
+		},
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(address, strconv.Itoa(port)))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	certificates := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certificates) == 0 {
+		return errors.New("domainer: no certificate presented")
+	}
+	leaf := certificates[0]
+
+	info := &TLSInfo{
+		Subject:     leaf.Subject.String(),
+		Issuer:      leaf.Issuer.String(),
+		DNSNames:    leaf.DNSNames,
+		NotBefore:   leaf.NotBefore,
+		NotAfter:    leaf.NotAfter,
+		MatchesHost: leaf.VerifyHostname(host) == nil,
+	}
+	for _, ip := range leaf.IPAddresses {
+		info.IPAddresses = append(info.IPAddresses, ip.String())
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, certificate := range certificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+	_, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         opts.RootCAs,
+		Intermediates: intermediates,
+	})
+	info.Trusted = err == nil
+
+	u.TLS = info
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package domainer
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInspectTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	port, err := strconv.Atoi(server.URL[strings.LastIndex(server.URL, ":")+1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+
+	inspectTests := []struct {
+		name        string
+		url         *URL
+		opts        TLSOptions
+		matchesHost bool
+		trusted     bool
+	}{
+		{
+			"IP address", &URL{Protocol: "https", Domain: "127.0.0.1", Port: port},
+			TLSOptions{RootCAs: roots}, true, true,
+		},
+		{
+			"Resolved host", &URL{Protocol: "https", Domain: "example", TLD: "com", Port: port, IPAddress: "127.0.0.1"},
+			TLSOptions{RootCAs: roots, Timeout: time.Second}, true, true,
+		},
+		{
+			"Host not in certificate", &URL{Protocol: "https", Subdomain: "www", Domain: "example", TLD: "org", Port: port, IPAddress: "127.0.0.1"},
+			TLSOptions{RootCAs: roots}, false, true,
+		},
+		{
+			"HTTP URL with explicit port", &URL{Protocol: "http", Domain: "127.0.0.1", Port: port},
+			TLSOptions{RootCAs: roots}, true, true,
+		},
+		{
+			"Untrusted certificate", &URL{Protocol: "https", Domain: "127.0.0.1", Port: port},
+			TLSOptions{RootCAs: x509.NewCertPool()}, true, false,
+		},
+	}
+
+	for _, tt := range inspectTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.url.InspectTLS(context.Background(), tt.opts); err != nil {
+				t.Fatal(err)
+			}
+
+			info := tt.url.TLS
+			if info == nil {
+				t.Fatal("Expected TLS to be set")
+			}
+			if info.MatchesHost != tt.matchesHost {
+				t.Errorf("MatchesHost: Expected %t, got %t", tt.matchesHost, info.MatchesHost)
+			}
+			if info.Trusted != tt.trusted {
+				t.Errorf("Trusted: Expected %t, got %t", tt.trusted, info.Trusted)
+			}
+			if !info.NotAfter.Equal(server.Certificate().NotAfter) {
+				t.Errorf("NotAfter: Expected %s, got %s", server.Certificate().NotAfter, info.NotAfter)
+			}
+			if len(info.DNSNames) == 0 || info.Issuer == "" {
+				t.Errorf("Expected DNSNames and Issuer to be set, got %q and '%s'", info.DNSNames, info.Issuer)
+			}
+		})
+	}
+}
+
+func TestInspectTLSUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	port, _ := strconv.Atoi(server.URL[strings.LastIndex(server.URL, ":")+1:])
+	server.Close()
+
+	u := &URL{Protocol: "https", Domain: "127.0.0.1", Port: port}
+	if err := u.InspectTLS(context.Background(), TLSOptions{Timeout: time.Second}); err == nil {
+		t.Errorf("Expected error for closed port")
+	}
+	if u.TLS != nil {
+		t.Errorf("Expected TLS to be nil, got %+v", u.TLS)
+	}
+}
+
+func TestInspectTLSNoHost(t *testing.T) {
+	reference, err := ParseReference("?page=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, u := range []*URL{reference, {}, {Protocol: "https", Port: 8443}} {
+		if err := u.InspectTLS(context.Background(), TLSOptions{Timeout: time.Second}); !errors.Is(err, ErrNoHost) {
+			t.Errorf("Expected '%v' for %+v, got '%v'", ErrNoHost, *u, err)
+		}
+	}
+}
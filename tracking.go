@@ -0,0 +1,163 @@
+package domainer
+
+import (
+	"strings"
+	"sync"
+)
+
+var (
+	// trackingParams are the query keys used for tracking. A trailing "*" matches every key with that prefix.
+	trackingParams = []string{
+		// Google
+		"utm_*", "gclid", "gclsrc", "dclid", "gbraid", "wbraid", "_ga", "_gl",
+		// Meta
+		"fbclid", "igshid",
+		// Microsoft
+		"msclkid",
+		// Mailchimp
+		"mc_cid", "mc_eid",
+		// HubSpot
+		"_hsenc", "_hsmi", "__hssc", "__hstc", "__hsfp", "hsctatracking",
+		// Marketo
+		"mkt_tok",
+		// Others
+		"yclid", "twclid", "ttclid", "li_fat_id", "s_cid", "vero_id", "vero_conv", "oly_anon_id", "oly_enc_id",
+		"rb_clickid", "wickedid", "epik",
+	}
+
+	// trackingParamsMu guards trackingParams.
+	trackingParamsMu sync.RWMutex
+)
+
+// RegisterTrackingParam adds query keys that are used for tracking. A trailing "*" matches every key with that prefix.
+// Example: RegisterTrackingParam("ref_src", "pk_*")
+func RegisterTrackingParam(keys ...string) {
+	trackingParamsMu.Lock()
+	defer trackingParamsMu.Unlock()
+
+	for _, key := range keys {
+		trackingParams = append(trackingParams, strings.ToLower(key))
+	}
+}
+
+// IsTrackingParam reports whether the query key is used for tracking. Keys are compared case-insensitively.
+// Example: true for "utm_source" and "fbclid"
+func IsTrackingParam(key string) bool {
+	key = strings.ToLower(key)
+
+	trackingParamsMu.RLock()
+	defer trackingParamsMu.RUnlock()
+
+	for _, param := range trackingParams {
+		if prefix := strings.TrimSuffix(param, "*"); prefix != param {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == param {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StripTracking removes all query values used for tracking and updates FullURL accordingly.
+// Only the tracking parameters are removed from FullURL, everything else is kept as it is, including parameters
+// that are not part of Query, like "?debug".
+// If the fragment has been split with ParseFragment, tracking parameters are removed from FragmentQuery and the
+// fragment as well.
+// Example: "https://example.com/?id=1&utm_source=newsletter" becomes "https://example.com/?id=1"
+func (u *URL) StripTracking() {
+	u.Query = withoutTracking(u.Query)
+
+	stripFragment := len(u.FragmentQuery) > 0
+	if stripFragment {
+		u.FragmentQuery = withoutTracking(u.FragmentQuery)
+		u.Fragment = stripTrackingFragment(u.Fragment)
+	}
+
+	if u.FullURL == "" {
+		u.FullURL = u.String()
+		return
+	}
+
+	u.FullURL = stripTrackingQuery(u.FullURL, stripFragment)
+}
+
+// withoutTracking returns the query values that are not used for tracking.
+func withoutTracking(query []Query) []Query {
+	var kept []Query
+	for _, q := range query {
+		if !IsTrackingParam(q.Key) {
+			kept = append(kept, q)
+		}
+	}
+
+	return kept
+}
+
+// stripTrackingQuery removes the tracking parameters from the raw query of the url, and from its fragment
+// if requested.
+func stripTrackingQuery(url string, stripFragment bool) string {
+	// The query ends at the fragment
+	rest, fragment, hasFragment := strings.Cut(url, "#")
+	rest, query, hasQuery := strings.Cut(rest, "?")
+
+	// An empty query is kept, a query of tracking parameters only is removed
+	if stripped := stripTrackingPairs(query); hasQuery && (stripped != "" || query == "") {
+		rest += "?" + stripped
+	}
+
+	if stripFragment {
+		fragment = stripTrackingFragment(fragment)
+	}
+	if hasFragment && (fragment != "" || !stripFragment) {
+		rest += "#" + fragment
+	}
+
+	return rest
+}
+
+// stripTrackingFragment removes the tracking parameters from a fragment that looks like a reference.
+// Example: "/route?tab=1&utm_source=x" becomes "/route?tab=1", "t=30&utm_source=x" becomes "t=30"
+func stripTrackingFragment(fragment string) string {
+	path, query, hasQuery := strings.Cut(fragment, "?")
+	if !hasQuery {
+		return stripTrackingPairs(fragment)
+	}
+
+	if query = stripTrackingPairs(query); query != "" {
+		return path + "?" + query
+	}
+
+	return path
+}
+
+// stripTrackingPairs removes the tracking parameters from raw key-value pairs separated by "&".
+func stripTrackingPairs(query string) string {
+	if query == "" {
+		return ""
+	}
+
+	parts := strings.Split(query, "&")
+	kept := parts[:0]
+	for _, part := range parts {
+		key, _, _ := strings.Cut(part, "=")
+		if !IsTrackingParam(key) {
+			kept = append(kept, part)
+		}
+	}
+
+	return strings.Join(kept, "&")
+}
+
+// markTracking sets IsTracking on all query values used for tracking.
+func (u *URL) markTracking() {
+	for i := range u.Query {
+		u.Query[i].IsTracking = IsTrackingParam(u.Query[i].Key)
+	}
+
+	for i := range u.FragmentQuery {
+		u.FragmentQuery[i].IsTracking = IsTrackingParam(u.FragmentQuery[i].Key)
+	}
+}
@@ -0,0 +1,148 @@
+package domainer
+
+import "testing"
+
+func TestIsTrackingParam(t *testing.T) {
+	trackingTests := []struct {
+		key      string
+		expected bool
+	}{
+		{"utm_source", true},
+		{"UTM_Campaign", true},
+		{"gclid", true},
+		{"fbclid", true},
+		{"msclkid", true},
+		{"mc_eid", true},
+		{"utm", false},
+		{"q", false},
+		{"page", false},
+	}
+
+	for _, tt := range trackingTests {
+		if got := IsTrackingParam(tt.key); got != tt.expected {
+			t.Errorf("IsTrackingParam(%q): Expected %t, got %t", tt.key, tt.expected, got)
+		}
+	}
+}
+
+func TestRegisterTrackingParam(t *testing.T) {
+	original := trackingParams
+	t.Cleanup(func() {
+		trackingParams = original
+	})
+
+	RegisterTrackingParam("Ref_Src", "pk_*")
+
+	for _, key := range []string{"ref_src", "pk_campaign", "pk_kwd"} {
+		if !IsTrackingParam(key) {
+			t.Errorf("Expected '%s' to be a tracking param", key)
+		}
+	}
+}
+
+func TestStripTracking(t *testing.T) {
+	stubLookup(t)
+
+	u, err := FromString("https://www.example.com/article?id=42&utm_source=newsletter&utm_medium=email&fbclid=abc#comments")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u.StripTracking()
+
+	expected := "https://www.example.com/article?id=42#comments"
+	if u.FullURL != expected {
+		t.Errorf("FullURL: Expected '%s', got '%s'", expected, u.FullURL)
+	}
+	if len(u.Query) != 1 || u.Query[0].Key != "id" {
+		t.Errorf("Query: Expected only 'id', got %+v", u.Query)
+	}
+
+	u, err = FromString("https://www.example.com/?gclid=abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u.StripTracking()
+
+	if u.FullURL != "https://www.example.com/" || u.Query != nil {
+		t.Errorf("Expected '%s' without query, got '%s' and %+v", "https://www.example.com/", u.FullURL, u.Query)
+	}
+
+	// Parameters that are not part of Query are kept
+	u, err = FromString("https://example.com/?debug&id=1&utm_source=x&x=a=b")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u.StripTracking()
+
+	if expected := "https://example.com/?debug&id=1&x=a=b"; u.FullURL != expected {
+		t.Errorf("FullURL: Expected '%s', got '%s'", expected, u.FullURL)
+	}
+}
+
+func TestStripTrackingFragment(t *testing.T) {
+	stubLookup(t)
+
+	stripTests := []struct {
+		url      string
+		opts     []Option
+		expected string
+		fragment string
+	}{
+		{"https://example.com/#/route?tab=1&utm_source=x", []Option{ParseFragment()}, "https://example.com/#/route?tab=1", "/route?tab=1"},
+		{"https://example.com/?id=1#!/route?utm_source=x", []Option{ParseFragment()}, "https://example.com/?id=1#!/route", "!/route"},
+		{"https://example.com/#t=30&utm_source=x", []Option{ParseFragment()}, "https://example.com/#t=30", "t=30"},
+		{"https://example.com/#utm_source=x", []Option{ParseFragment()}, "https://example.com/", ""},
+		{"https://example.com/#utm_source=x", nil, "https://example.com/#utm_source=x", "utm_source=x"},
+	}
+
+	for _, tt := range stripTests {
+		t.Run(tt.url, func(t *testing.T) {
+			u, err := FromString(tt.url, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			u.StripTracking()
+
+			if u.FullURL != tt.expected {
+				t.Errorf("FullURL: Expected '%s', got '%s'", tt.expected, u.FullURL)
+			}
+			if u.Fragment != tt.fragment {
+				t.Errorf("Fragment: Expected '%s', got '%s'", tt.fragment, u.Fragment)
+			}
+			for _, q := range u.FragmentQuery {
+				if IsTrackingParam(q.Key) {
+					t.Errorf("Expected '%s' to be removed from FragmentQuery", q.Key)
+				}
+			}
+		})
+	}
+}
+
+func TestMarkTracking(t *testing.T) {
+	stubLookup(t)
+
+	u, err := FromString("https://www.example.com/?id=42&utm_source=newsletter", MarkTracking())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.Query[0].IsTracking {
+		t.Errorf("Expected '%s' not to be marked", u.Query[0].Key)
+	}
+	if !u.Query[1].IsTracking {
+		t.Errorf("Expected '%s' to be marked", u.Query[1].Key)
+	}
+
+	u, err = FromString("https://www.example.com/?utm_source=newsletter")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.Query[0].IsTracking {
+		t.Errorf("Expected no marks without MarkTracking")
+	}
+}